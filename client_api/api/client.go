@@ -1,10 +1,18 @@
 package api
 
 import (
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/docker/notary/client"
 	"github.com/docker/notary/client/changelist"
+	"github.com/docker/notary/storage"
 	"github.com/docker/notary/tuf/data"
 	"github.com/docker/notary/tuf/signed"
 	"golang.org/x/net/context"
@@ -17,12 +25,51 @@ type Client struct {
 }
 
 func NewClient(conn *grpc.ClientConn, gun data.GUN) *Client {
+	nc := NewNotaryClient(conn)
 	return &Client{
-		client: NewNotaryClient(conn),
+		client: nc,
+		cs:     &CryptoService{client: nc},
 		gun:    gun,
 	}
 }
 
+// wrapNotaryError maps a gRPC status produced by toStatus back into the
+// concrete Go error type that downstream tooling (e.g. docker/cli trust
+// code) type-switches on. Errors whose code/message don't match one of
+// those well-known classes, or that aren't gRPC statuses at all, are
+// returned unchanged.
+func wrapNotaryError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.Unavailable:
+		if st.Message() == offlineMessage {
+			return storage.ErrOffline{}
+		}
+	case codes.NotFound:
+		if st.Message() == repositoryNotExistMessage {
+			return client.ErrRepositoryNotExist{}
+		}
+	case codes.FailedPrecondition:
+		if st.Message() == repoNotInitializedMessage {
+			return client.ErrRepoNotInitialized{}
+		}
+		var found, required int
+		if _, scanErr := fmt.Sscanf(st.Message(), insufficientSignaturesFormat, &found, &required); scanErr == nil {
+			return signed.ErrInsufficientSignatures{FoundKeys: found, NeededKeys: required}
+		}
+	}
+
+	return err
+}
+
 func (c *Client) Initialize(rootKeyIDs []string, serverManagedRoles ...data.RoleName) error {
 	roles := make([]string, len(serverManagedRoles))
 	for index, value := range serverManagedRoles {
@@ -30,20 +77,85 @@ func (c *Client) Initialize(rootKeyIDs []string, serverManagedRoles ...data.Role
 	}
 
 	initMsg := &InitMessage{
-		RootKeyIDs: rootKeyIDs,
-		ServerManagedRoles: &RoleNameList{Roles:roles},
+		RootKeyIDs:         rootKeyIDs,
+		ServerManagedRoles: &RoleNameList{Roles: roles},
 	}
 	_, err := c.client.Initialize(context.Background(), initMsg)
-	return err
+	return wrapNotaryError(err)
+}
+
+// InitializeWithCertificate bootstraps the repository using the supplied
+// root certificates as the root role's keys, instead of generating a new
+// root key. This lets a repo be rooted in an existing corporate CA or
+// HSM-backed certificate rather than a key synthesized on the fly.
+func (c *Client) InitializeWithCertificate(rootKeyIDs []string, rootCerts []data.PublicKey, serverManagedRoles ...data.RoleName) error {
+	roles := make([]string, len(serverManagedRoles))
+	for index, value := range serverManagedRoles {
+		roles[index] = value.String()
+	}
+
+	initMsg := &InitMessage{
+		RootKeyIDs:         rootKeyIDs,
+		ServerManagedRoles: &RoleNameList{Roles: roles},
+		RootCerts:          publicKeysToProto(rootCerts),
+	}
+	_, err := c.client.InitializeWithCertificate(context.Background(), initMsg)
+	return wrapNotaryError(err)
 }
 
 func (c *Client) Publish() error {
 	_, err := c.client.Publish(context.Background(), &Empty{})
-	return err
+	return wrapNotaryError(err)
+}
+
+// DeleteTrustData always wipes the local TUF cache for this gun. When
+// deleteRemote is true it additionally asks the server to tombstone the
+// gun's metadata so a subsequent publish starts from an empty root. An error
+// reported by the server, including a failed local wipe, is returned as is.
+// If the server's response does not confirm the local wipe, an
+// ErrTrustDataLocalDeleteFailed is returned; if only the remote half could
+// not be confirmed, an
+// ErrTrustDataRemoteDeleteFailed is returned so automation (e.g. CI
+// teardown) knows to retry that half rather than assuming it's done.
+func (c *Client) DeleteTrustData(deleteRemote bool) error {
+	resp, err := c.client.DeleteTrustData(context.Background(), &DeleteTrustDataRequest{
+		Gun:          c.gun.String(),
+		DeleteRemote: deleteRemote,
+	})
+	if err != nil {
+		return wrapNotaryError(err)
+	}
+
+	if !resp.LocalDeleted {
+		return ErrTrustDataLocalDeleteFailed{GUN: c.gun}
+	}
+
+	if deleteRemote && !resp.RemoteDeleted {
+		return ErrTrustDataRemoteDeleteFailed{GUN: c.gun}
+	}
+
+	return nil
 }
 
-func (c *Client) DeleteTrustData(deleteRemote bool) error {
-	return ErrNotImplemented
+// ErrTrustDataLocalDeleteFailed indicates DeleteTrustData could not confirm
+// that the local TUF cache for GUN was wiped.
+type ErrTrustDataLocalDeleteFailed struct {
+	GUN data.GUN
+}
+
+func (e ErrTrustDataLocalDeleteFailed) Error() string {
+	return fmt.Sprintf("could not confirm local trust data was deleted for %s", e.GUN)
+}
+
+// ErrTrustDataRemoteDeleteFailed indicates DeleteTrustData removed the
+// local TUF cache but could not confirm the remote GUN metadata was
+// tombstoned, so the remote side still needs to be retried.
+type ErrTrustDataRemoteDeleteFailed struct {
+	GUN data.GUN
+}
+
+func (e ErrTrustDataRemoteDeleteFailed) Error() string {
+	return fmt.Sprintf("local trust data deleted for %s, but remote deletion could not be confirmed", e.GUN)
 }
 
 func (c *Client) AddTarget(target *client.Target, roles ...data.RoleName) error {
@@ -54,7 +166,7 @@ func (c *Client) AddTarget(target *client.Target, roles ...data.RoleName) error
 		Hashes: target.Hashes,
 	}
 	_, err := c.client.AddTarget(context.Background(), t)
-	return err
+	return wrapNotaryError(err)
 }
 
 func (c *Client) RemoveTarget(targetName string, roles ...data.RoleName) error {
@@ -63,7 +175,7 @@ func (c *Client) RemoveTarget(targetName string, roles ...data.RoleName) error {
 		Name: targetName,
 	}
 	_, err := c.client.RemoveTarget(context.Background(), t)
-	return err
+	return wrapNotaryError(err)
 }
 
 func (c *Client) ListTargets(roles ...data.RoleName) ([]*client.TargetWithRole, error) {
@@ -72,7 +184,7 @@ func (c *Client) ListTargets(roles ...data.RoleName) ([]*client.TargetWithRole,
 		rolesList[index] = value.String()
 	}
 
-	targetWithRoleList, err := c.client.ListTargets(context.Background(), &RoleNameList{Roles:rolesList})
+	targetWithRoleList, err := c.client.ListTargets(context.Background(), &RoleNameList{Roles: rolesList})
 	if err != nil {
 		return []*client.TargetWithRole{}, err
 	}
@@ -85,7 +197,7 @@ func (c *Client) ListTargets(roles ...data.RoleName) ([]*client.TargetWithRole,
 		r := target.Role
 
 		currTarget := client.Target{
-			Name: t.GetName(),
+			Name:   t.GetName(),
 			Hashes: data.Hashes(t.Hashes),
 			Length: t.GetLength(),
 		}
@@ -94,7 +206,7 @@ func (c *Client) ListTargets(roles ...data.RoleName) ([]*client.TargetWithRole,
 
 		targetWithRole := &client.TargetWithRole{
 			Target: currTarget,
-			Role: currRole,
+			Role:   currRole,
 		}
 
 		res[index] = targetWithRole
@@ -103,6 +215,15 @@ func (c *Client) ListTargets(roles ...data.RoleName) ([]*client.TargetWithRole,
 	return res, nil
 }
 
+// ReleasesRole is the conventional "top level" delegation used by Docker
+// trust tooling. It is tried before falling back to the canonical targets
+// role when resolving a tag.
+const ReleasesRole = data.RoleName("targets/releases")
+
+// GetTargetByName looks up name in the given roles, in order, and returns
+// the first match. The server walks the delegation tree using the exact
+// role order supplied, so callers that care about precedence (e.g.
+// targets/releases before targets) must pass roles in that order.
 func (c *Client) GetTargetByName(name string, roles ...data.RoleName) (*client.TargetWithRole, error) {
 	rolesList := make([]string, len(roles))
 	for index, value := range roles {
@@ -110,13 +231,13 @@ func (c *Client) GetTargetByName(name string, roles ...data.RoleName) (*client.T
 	}
 
 	targetByNameAction := &TargetByNameAction{
-		Name: name,
-		Roles: &RoleNameList{Roles:rolesList},
+		Name:  name,
+		Roles: &RoleNameList{Roles: rolesList},
 	}
 
 	targetWithRole, err := c.client.GetTargetByName(context.Background(), targetByNameAction)
 	if err != nil {
-		return nil, err
+		return nil, wrapNotaryError(err)
 	}
 
 	target := targetWithRole.TargetWithRole.Target
@@ -124,7 +245,7 @@ func (c *Client) GetTargetByName(name string, roles ...data.RoleName) (*client.T
 
 	res := &client.TargetWithRole{
 		Target: client.Target{
-			Name: target.GetName(),
+			Name:   target.GetName(),
 			Hashes: data.Hashes(target.Hashes),
 			Length: target.GetLength(),
 		},
@@ -134,6 +255,53 @@ func (c *Client) GetTargetByName(name string, roles ...data.RoleName) (*client.T
 	return res, nil
 }
 
+// GetTargetsByName returns every match for name across all roles that claim
+// the path, unlike GetTargetByName which stops at the first hit. This is
+// useful when the caller needs to know every delegation signing a path,
+// not just the highest-precedence one.
+func (c *Client) GetTargetsByName(name string, roles ...data.RoleName) ([]*client.TargetWithRole, error) {
+	rolesList := make([]string, len(roles))
+	for index, value := range roles {
+		rolesList[index] = value.String()
+	}
+
+	targetByNameAction := &TargetByNameAction{
+		Name:  name,
+		Roles: &RoleNameList{Roles: rolesList},
+	}
+
+	targetWithRoleList, err := c.client.GetTargetsByName(context.Background(), targetByNameAction)
+	if err != nil {
+		return nil, wrapNotaryError(err)
+	}
+
+	targets := targetWithRoleList.TargetWithRoleNameList.Targets
+	res := make([]*client.TargetWithRole, len(targets))
+
+	for index, target := range targets {
+		t := target.Target
+		r := target.Role
+
+		res[index] = &client.TargetWithRole{
+			Target: client.Target{
+				Name:   t.GetName(),
+				Hashes: data.Hashes(t.Hashes),
+				Length: t.GetLength(),
+			},
+			Role: data.RoleName(r),
+		}
+	}
+
+	return res, nil
+}
+
+// ReleasesTarget resolves name by preferring the ReleasesRole delegation and
+// falling back to the canonical targets role, matching the precedence
+// expected by Docker's trusted-reference resolution.
+func (c *Client) ReleasesTarget(name string) (*client.TargetWithRole, error) {
+	return c.GetTargetByName(name, ReleasesRole, data.CanonicalTargetsRole)
+}
+
 func (c *Client) GetAllTargetMetadataByName(name string) ([]client.TargetSignedStruct, error) {
 	targetName := &TargetName{
 		Name: name,
@@ -152,9 +320,8 @@ func (c *Client) GetAllTargetMetadataByName(name string) ([]client.TargetSignedS
 		s := value.Signatures
 		t := value.Target
 
-
 		currTarget := client.Target{
-			Name: t.Name,
+			Name:   t.Name,
 			Hashes: t.Hashes,
 			Length: t.Length,
 		}
@@ -163,9 +330,9 @@ func (c *Client) GetAllTargetMetadataByName(name string) ([]client.TargetSignedS
 		for indexS, sig := range s {
 			currSignature := data.Signature{
 				Signature: sig.Signature,
-				KeyID: sig.KeyID,
-				IsValid: sig.IsValid,
-				Method: data.SigAlgorithm(sig.Method),
+				KeyID:     sig.KeyID,
+				IsValid:   sig.IsValid,
+				Method:    data.SigAlgorithm(sig.Method),
 			}
 
 			currSignatures[indexS] = currSignature
@@ -178,16 +345,16 @@ func (c *Client) GetAllTargetMetadataByName(name string) ([]client.TargetSignedS
 
 		currRole := data.DelegationRole{
 			BaseRole: data.BaseRole{
-				Keys: currKeys,
-				Name: data.RoleName(r.Name),
+				Keys:      currKeys,
+				Name:      data.RoleName(r.Name),
 				Threshold: int(r.Threshold), // FIXME
 			},
 			Paths: r.Paths,
 		}
 
 		res[indexT] = client.TargetSignedStruct{
-			Role: currRole,
-			Target: currTarget,
+			Role:       currRole,
+			Target:     currTarget,
 			Signatures: currSignatures,
 		}
 	}
@@ -230,9 +397,9 @@ func (c *Client) ListRoles() ([]client.RoleWithSignatures, error) {
 		for indexSig, sig := range value.Signatures {
 			currSignature := data.Signature{
 				Signature: sig.Signature,
-				KeyID: sig.KeyID,
-				IsValid: sig.IsValid,
-				Method: data.SigAlgorithm(sig.Method),
+				KeyID:     sig.KeyID,
+				IsValid:   sig.IsValid,
+				Method:    data.SigAlgorithm(sig.Method),
 			}
 
 			currSignatures[indexSig] = currSignature
@@ -240,16 +407,16 @@ func (c *Client) ListRoles() ([]client.RoleWithSignatures, error) {
 
 		currRole := data.Role{
 			RootRole: data.RootRole{
-				KeyIDs: r.RootRole.KeyIDs,
+				KeyIDs:    r.RootRole.KeyIDs,
 				Threshold: int(r.RootRole.Threshold), // FIXME
 			},
-			Name: data.RoleName(r.Name),
+			Name:  data.RoleName(r.Name),
 			Paths: r.Paths,
 		}
 
 		res[index] = client.RoleWithSignatures{
 			Signatures: currSignatures,
-			Role: currRole,
+			Role:       currRole,
 		}
 	}
 
@@ -262,65 +429,149 @@ func (c *Client) GetDelegationRoles() ([]data.Role, error) {
 		return nil, err
 	}
 
-	res := make([]data.Role, len(roleListResp.RoleList.Roles))
-	for index, role := range roleListResp.RoleList.Roles {
+	res := make([]data.Role, len(roleListResp.Roles))
+	for index, role := range roleListResp.Roles {
 		currRole := data.Role{
 			RootRole: data.RootRole{
-				KeyIDs: role.RootRole.KeyIDs,
+				KeyIDs:    role.RootRole.KeyIDs,
 				Threshold: int(role.RootRole.Threshold),
 			},
-			Name: data.RoleName(role.Name),
+			Name:  data.RoleName(role.Name),
 			Paths: role.Paths,
 		}
 
 		res[index] = currRole
 	}
 
-	return nil, ErrNotImplemented
+	return res, nil
+}
+
+func publicKeysToProto(keys []data.PublicKey) []*PublicKey {
+	res := make([]*PublicKey, len(keys))
+	for index, key := range keys {
+		res[index] = &PublicKey{
+			Algorithm: key.Algorithm(),
+			Public:    key.Public(),
+		}
+	}
+	return res
 }
 
 func (c *Client) AddDelegation(name data.RoleName, delegationKeys []data.PublicKey, paths []string) error {
-	return ErrNotImplemented
+	_, err := c.client.AddDelegation(context.Background(), &DelegationRoleAndKeysAndPaths{
+		Gun:   c.gun.String(),
+		Name:  name.String(),
+		Keys:  publicKeysToProto(delegationKeys),
+		Paths: paths,
+	})
+	return wrapNotaryError(err)
 }
 
 func (c *Client) AddDelegationRoleAndKeys(name data.RoleName, delegationKeys []data.PublicKey) error {
-	return ErrNotImplemented
+	_, err := c.client.AddDelegationRoleAndKeys(context.Background(), &DelegationRoleAndKeys{
+		Gun:  c.gun.String(),
+		Name: name.String(),
+		Keys: publicKeysToProto(delegationKeys),
+	})
+	return wrapNotaryError(err)
 }
 
 func (c *Client) AddDelegationPaths(name data.RoleName, paths []string) error {
-	return ErrNotImplemented
+	_, err := c.client.AddDelegationPaths(context.Background(), &DelegationPaths{
+		Gun:   c.gun.String(),
+		Name:  name.String(),
+		Paths: paths,
+	})
+	return wrapNotaryError(err)
 }
 
 func (c *Client) RemoveDelegationKeysAndPaths(name data.RoleName, keyIDs, paths []string) error {
-	return ErrNotImplemented
+	_, err := c.client.RemoveDelegationKeysAndPaths(context.Background(), &DelegationKeysAndPaths{
+		Gun:    c.gun.String(),
+		Name:   name.String(),
+		KeyIDs: keyIDs,
+		Paths:  paths,
+	})
+	return wrapNotaryError(err)
 }
 
 func (c *Client) RemoveDelegationRole(name data.RoleName) error {
-	return ErrNotImplemented
+	_, err := c.client.RemoveDelegationRole(context.Background(), &DelegationName{
+		Gun:  c.gun.String(),
+		Name: name.String(),
+	})
+	return wrapNotaryError(err)
 }
 
 func (c *Client) RemoveDelegationPaths(name data.RoleName, paths []string) error {
-	return ErrNotImplemented
+	_, err := c.client.RemoveDelegationPaths(context.Background(), &DelegationPaths{
+		Gun:   c.gun.String(),
+		Name:  name.String(),
+		Paths: paths,
+	})
+	return wrapNotaryError(err)
 }
 
 func (c *Client) RemoveDelegationKeys(name data.RoleName, keyIDs []string) error {
-	return ErrNotImplemented
+	_, err := c.client.RemoveDelegationKeys(context.Background(), &DelegationKeys{
+		Gun:    c.gun.String(),
+		Name:   name.String(),
+		KeyIDs: keyIDs,
+	})
+	return wrapNotaryError(err)
 }
 
 func (c *Client) ClearDelegationPaths(name data.RoleName) error {
-	return ErrNotImplemented
+	_, err := c.client.ClearDelegationPaths(context.Background(), &DelegationName{
+		Gun:  c.gun.String(),
+		Name: name.String(),
+	})
+	return wrapNotaryError(err)
 }
 
+// Witness flags roles to be re-signed on the next publish, so that a
+// rotated or expired delegation picks up fresh, validly-signed metadata
+// without editing target content. It returns the roles that were queued.
 func (c *Client) Witness(roles ...data.RoleName) ([]data.RoleName, error) {
-	return nil, ErrNotImplemented
+	rolesList := make([]string, len(roles))
+	for index, value := range roles {
+		rolesList[index] = value.String()
+	}
+
+	resp, err := c.client.Witness(context.Background(), &WitnessRequest{
+		Gun:   c.gun.String(),
+		Roles: rolesList,
+	})
+	if err != nil {
+		return nil, wrapNotaryError(err)
+	}
+
+	witnessed := make([]data.RoleName, len(resp.WitnessedRoles))
+	for index, role := range resp.WitnessedRoles {
+		witnessed[index] = data.RoleName(role)
+	}
+	return witnessed, nil
 }
 
+// RotateKey rotates role to a new key. If serverManagesKey is true the
+// signer generates the replacement and the client never sees the private
+// half; otherwise keyList must name one or more local key IDs to rotate
+// to. Rotating a delegated role (anything but the top-level root, targets,
+// snapshot, and timestamp roles) is rejected server-side, since delegation
+// key rotation goes through AddDelegationRoleAndKeys instead.
 func (c *Client) RotateKey(role data.RoleName, serverManagesKey bool, keyList []string) error {
-	return ErrNotImplemented
+	_, err := c.client.RotateKey(context.Background(), &RotateKeyRequest{
+		Gun:              c.gun.String(),
+		Role:             role.String(),
+		ServerManagesKey: serverManagesKey,
+		KeyIDs:           keyList,
+	})
+	return wrapNotaryError(err)
 }
 
+// SetLegacyVersions is a no-op: this client has no local TUF metadata cache
+// to carry legacy (pre-new-format) signatures forward from.
 func (c *Client) SetLegacyVersions(n int) {
-	// do nothing. New client API based repos only support new format root key rotation
 }
 
 func (c *Client) CryptoService() signed.CryptoService {
@@ -331,6 +582,15 @@ func (c *Client) GetGUN() data.GUN {
 	return c.gun
 }
 
+// ErrNotImplemented is returned by CryptoService methods that this
+// remote-signing bridge has no way to support.
+var ErrNotImplemented = errors.New("not implemented")
+
+// CryptoService is a signed.CryptoService that proxies all key material and
+// signing operations to a remote signer process over gRPC. Private key
+// bytes never cross this boundary: Create and GetKey only ever see public
+// material, and signing is performed by issuing a Sign RPC carrying the key
+// ID and payload.
 type CryptoService struct {
 	client NotaryClient
 }
@@ -338,41 +598,130 @@ type CryptoService struct {
 // Create issues a new key pair and is responsible for loading
 // the private key into the appropriate signing service.
 func (cs *CryptoService) Create(role data.RoleName, gun data.GUN, algorithm string) (data.PublicKey, error) {
-	return nil, ErrNotImplemented
+	pubKey, err := cs.client.CreateKey(context.Background(), &CreateKeyRequest{
+		Role:      role.String(),
+		Gun:       gun.String(),
+		Algorithm: algorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data.NewPublicKey(pubKey.Algorithm, pubKey.Public), nil
 }
 
-// AddKey adds a private key to the specified role and gun
+// AddKey adds a private key to the specified role and gun. This bridge has
+// no way to hand private key material to the remote signer without
+// violating the "private keys never leave the signer" invariant the rest
+// of this CryptoService relies on, so importing keys isn't supported here;
+// use Create to have the signer generate one instead.
 func (cs *CryptoService) AddKey(role data.RoleName, gun data.GUN, key data.PrivateKey) error {
 	return ErrNotImplemented
 }
 
 // GetKey retrieves the public key if present, otherwise it returns nil
 func (cs *CryptoService) GetKey(keyID string) data.PublicKey {
-	return nil
+	keyInfo, err := cs.client.GetKey(context.Background(), &KeyID{KeyID: keyID})
+	if err != nil {
+		return nil
+	}
+
+	return data.NewPublicKey(keyInfo.Algorithm, keyInfo.Public)
 }
 
 // GetPrivateKey retrieves the private key and role if present and retrievable,
 // otherwise it returns nil and an error
 func (cs *CryptoService) GetPrivateKey(keyID string) (data.PrivateKey, data.RoleName, error) {
-	return nil, "", ErrNotImplemented
+	keyInfo, err := cs.client.GetKey(context.Background(), &KeyID{KeyID: keyID})
+	if err != nil {
+		return nil, "", err
+	}
+
+	pubKey := data.NewPublicKey(keyInfo.Algorithm, keyInfo.Public)
+	privKey := &remotePrivateKey{PublicKey: pubKey, client: cs.client}
+
+	return privKey, data.RoleName(keyInfo.Role), nil
 }
 
 // RemoveKey deletes the specified key, and returns an error only if the key
 // removal fails. If the key doesn't exist, no error should be returned.
 func (cs *CryptoService) RemoveKey(keyID string) error {
-	return ErrNotImplemented
+	_, err := cs.client.RemoveKey(context.Background(), &KeyID{KeyID: keyID})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
 }
 
 // ListKeys returns a list of key IDs for the role, or an empty list or
 // nil if there are no keys.
 func (cs *CryptoService) ListKeys(role data.RoleName) []string {
-	keys := cs.ListKeys(role)
-	return nil
+	keyList, err := cs.client.ListKeys(context.Background(), &RoleQuery{Role: role.String()})
+	if err != nil {
+		return nil
+	}
+
+	return keyList.KeyIDs
 }
 
 // ListAllKeys returns a map of all available signing key IDs to role, or
 // an empty map or nil if there are no keys.
 func (cs *CryptoService) ListAllKeys() map[string]data.RoleName {
-	keys := cs.ListAllKeys()
-	return keys
-}
\ No newline at end of file
+	keyRoleMap, err := cs.client.ListAllKeys(context.Background(), &Empty{})
+	if err != nil {
+		return nil
+	}
+
+	res := make(map[string]data.RoleName, len(keyRoleMap.Entries))
+	for _, entry := range keyRoleMap.Entries {
+		res[entry.KeyID] = data.RoleName(entry.Role)
+	}
+
+	return res
+}
+
+// remotePrivateKey is a data.PrivateKey whose private half never leaves the
+// remote signer: Private() deliberately returns nil, and signing is
+// performed by issuing a Sign RPC against the key's ID.
+type remotePrivateKey struct {
+	data.PublicKey
+	client NotaryClient
+}
+
+// Private always returns nil: the private key material lives only in the
+// remote signer and is never transmitted to this process.
+func (k *remotePrivateKey) Private() []byte {
+	return nil
+}
+
+// Sign issues a Sign RPC carrying this key's ID and the payload, and
+// returns the signature computed by the remote signer.
+func (k *remotePrivateKey) Sign(rand io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	resp, err := k.client.Sign(context.Background(), &SignRequest{
+		KeyID:   k.ID(),
+		Payload: msg,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Signature, nil
+}
+
+// CryptoSigner returns a crypto.Signer backed by the same remote Sign RPC,
+// for callers that need the stdlib interface rather than data.PrivateKey.
+func (k *remotePrivateKey) CryptoSigner() crypto.Signer {
+	return &remoteSigner{key: k}
+}
+
+type remoteSigner struct {
+	key *remotePrivateKey
+}
+
+func (s *remoteSigner) Public() crypto.PublicKey {
+	return s.key.PublicKey
+}
+
+func (s *remoteSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.key.Sign(rand, digest, opts)
+}
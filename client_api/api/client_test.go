@@ -0,0 +1,726 @@
+package api
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/docker/notary/client"
+	"github.com/docker/notary/storage"
+	"github.com/docker/notary/tuf/data"
+	"github.com/docker/notary/tuf/signed"
+	"golang.org/x/net/context"
+)
+
+// fakeNotaryClient is a NotaryClient whose behavior is supplied per-test via
+// the function fields below; unset fields return a zero-value response and a
+// nil error, which is all the tests that don't care about a given RPC need.
+type fakeNotaryClient struct {
+	initializeFn                   func(*InitMessage) (*Empty, error)
+	initializeWithCertificateFn    func(*InitMessage) (*Empty, error)
+	publishFn                      func(*Empty) (*Empty, error)
+	deleteTrustDataFn              func(*DeleteTrustDataRequest) (*DeleteTrustDataResponse, error)
+	addTargetFn                    func(*Target) (*Empty, error)
+	removeTargetFn                 func(*Target) (*Empty, error)
+	listTargetsFn                  func(*RoleNameList) (*TargetWithRoleList, error)
+	getTargetByNameFn              func(*TargetByNameAction) (*TargetWithRoleResponse, error)
+	getTargetsByNameFn             func(*TargetByNameAction) (*TargetWithRoleList, error)
+	getAllTargetMetadataFn         func(*TargetName) (*TargetSignedListResponse, error)
+	getChangelistFn                func(*Empty) (*ChangelistResponse, error)
+	listRolesFn                    func(*Empty) (*RoleWithSignaturesListResponse, error)
+	getDelegationRolesFn           func(*Empty) (*RoleList, error)
+	addDelegationFn                func(*DelegationRoleAndKeysAndPaths) (*Empty, error)
+	addDelegationRoleAndKeysFn     func(*DelegationRoleAndKeys) (*Empty, error)
+	addDelegationPathsFn           func(*DelegationPaths) (*Empty, error)
+	removeDelegationKeysAndPathsFn func(*DelegationKeysAndPaths) (*Empty, error)
+	removeDelegationRoleFn         func(*DelegationName) (*Empty, error)
+	removeDelegationPathsFn        func(*DelegationPaths) (*Empty, error)
+	removeDelegationKeysFn         func(*DelegationKeys) (*Empty, error)
+	clearDelegationPathsFn         func(*DelegationName) (*Empty, error)
+	witnessFn                      func(*WitnessRequest) (*WitnessResponse, error)
+	rotateKeyFn                    func(*RotateKeyRequest) (*Empty, error)
+	createKeyFn                    func(*CreateKeyRequest) (*PublicKey, error)
+	getKeyFn                       func(*KeyID) (*KeyInfo, error)
+	removeKeyFn                    func(*KeyID) (*Empty, error)
+	listKeysFn                     func(*RoleQuery) (*KeyIDList, error)
+	listAllKeysFn                  func(*Empty) (*KeyRoleMap, error)
+	signFn                         func(*SignRequest) (*SignResponse, error)
+}
+
+func (f *fakeNotaryClient) Initialize(ctx context.Context, in *InitMessage, opts ...grpc.CallOption) (*Empty, error) {
+	if f.initializeFn != nil {
+		return f.initializeFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) InitializeWithCertificate(ctx context.Context, in *InitMessage, opts ...grpc.CallOption) (*Empty, error) {
+	if f.initializeWithCertificateFn != nil {
+		return f.initializeWithCertificateFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) Publish(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	if f.publishFn != nil {
+		return f.publishFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) DeleteTrustData(ctx context.Context, in *DeleteTrustDataRequest, opts ...grpc.CallOption) (*DeleteTrustDataResponse, error) {
+	if f.deleteTrustDataFn != nil {
+		return f.deleteTrustDataFn(in)
+	}
+	return &DeleteTrustDataResponse{}, nil
+}
+
+func (f *fakeNotaryClient) AddTarget(ctx context.Context, in *Target, opts ...grpc.CallOption) (*Empty, error) {
+	if f.addTargetFn != nil {
+		return f.addTargetFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) RemoveTarget(ctx context.Context, in *Target, opts ...grpc.CallOption) (*Empty, error) {
+	if f.removeTargetFn != nil {
+		return f.removeTargetFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) ListTargets(ctx context.Context, in *RoleNameList, opts ...grpc.CallOption) (*TargetWithRoleList, error) {
+	if f.listTargetsFn != nil {
+		return f.listTargetsFn(in)
+	}
+	return &TargetWithRoleList{TargetWithRoleNameList: &TargetWithRoleNameList{}}, nil
+}
+
+func (f *fakeNotaryClient) GetTargetByName(ctx context.Context, in *TargetByNameAction, opts ...grpc.CallOption) (*TargetWithRoleResponse, error) {
+	if f.getTargetByNameFn != nil {
+		return f.getTargetByNameFn(in)
+	}
+	return &TargetWithRoleResponse{TargetWithRole: &TargetWithRole{Target: &Target{}}}, nil
+}
+
+func (f *fakeNotaryClient) GetTargetsByName(ctx context.Context, in *TargetByNameAction, opts ...grpc.CallOption) (*TargetWithRoleList, error) {
+	if f.getTargetsByNameFn != nil {
+		return f.getTargetsByNameFn(in)
+	}
+	return &TargetWithRoleList{TargetWithRoleNameList: &TargetWithRoleNameList{}}, nil
+}
+
+func (f *fakeNotaryClient) GetAllTargetMetadataByName(ctx context.Context, in *TargetName, opts ...grpc.CallOption) (*TargetSignedListResponse, error) {
+	if f.getAllTargetMetadataFn != nil {
+		return f.getAllTargetMetadataFn(in)
+	}
+	return &TargetSignedListResponse{TargetSignedList: &TargetSignedList{}}, nil
+}
+
+func (f *fakeNotaryClient) GetChangelist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangelistResponse, error) {
+	if f.getChangelistFn != nil {
+		return f.getChangelistFn(in)
+	}
+	return &ChangelistResponse{Changelist: &Changelist{}}, nil
+}
+
+func (f *fakeNotaryClient) ListRoles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoleWithSignaturesListResponse, error) {
+	if f.listRolesFn != nil {
+		return f.listRolesFn(in)
+	}
+	return &RoleWithSignaturesListResponse{RoleWithSignaturesList: &RoleWithSignaturesList{}}, nil
+}
+
+func (f *fakeNotaryClient) GetDelegationRoles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoleList, error) {
+	if f.getDelegationRolesFn != nil {
+		return f.getDelegationRolesFn(in)
+	}
+	return &RoleList{}, nil
+}
+
+func (f *fakeNotaryClient) AddDelegation(ctx context.Context, in *DelegationRoleAndKeysAndPaths, opts ...grpc.CallOption) (*Empty, error) {
+	if f.addDelegationFn != nil {
+		return f.addDelegationFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) AddDelegationRoleAndKeys(ctx context.Context, in *DelegationRoleAndKeys, opts ...grpc.CallOption) (*Empty, error) {
+	if f.addDelegationRoleAndKeysFn != nil {
+		return f.addDelegationRoleAndKeysFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) AddDelegationPaths(ctx context.Context, in *DelegationPaths, opts ...grpc.CallOption) (*Empty, error) {
+	if f.addDelegationPathsFn != nil {
+		return f.addDelegationPathsFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) RemoveDelegationKeysAndPaths(ctx context.Context, in *DelegationKeysAndPaths, opts ...grpc.CallOption) (*Empty, error) {
+	if f.removeDelegationKeysAndPathsFn != nil {
+		return f.removeDelegationKeysAndPathsFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) RemoveDelegationRole(ctx context.Context, in *DelegationName, opts ...grpc.CallOption) (*Empty, error) {
+	if f.removeDelegationRoleFn != nil {
+		return f.removeDelegationRoleFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) RemoveDelegationPaths(ctx context.Context, in *DelegationPaths, opts ...grpc.CallOption) (*Empty, error) {
+	if f.removeDelegationPathsFn != nil {
+		return f.removeDelegationPathsFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) RemoveDelegationKeys(ctx context.Context, in *DelegationKeys, opts ...grpc.CallOption) (*Empty, error) {
+	if f.removeDelegationKeysFn != nil {
+		return f.removeDelegationKeysFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) ClearDelegationPaths(ctx context.Context, in *DelegationName, opts ...grpc.CallOption) (*Empty, error) {
+	if f.clearDelegationPathsFn != nil {
+		return f.clearDelegationPathsFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) Witness(ctx context.Context, in *WitnessRequest, opts ...grpc.CallOption) (*WitnessResponse, error) {
+	if f.witnessFn != nil {
+		return f.witnessFn(in)
+	}
+	return &WitnessResponse{}, nil
+}
+
+func (f *fakeNotaryClient) RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	if f.rotateKeyFn != nil {
+		return f.rotateKeyFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*PublicKey, error) {
+	if f.createKeyFn != nil {
+		return f.createKeyFn(in)
+	}
+	return &PublicKey{}, nil
+}
+
+func (f *fakeNotaryClient) GetKey(ctx context.Context, in *KeyID, opts ...grpc.CallOption) (*KeyInfo, error) {
+	if f.getKeyFn != nil {
+		return f.getKeyFn(in)
+	}
+	return &KeyInfo{}, nil
+}
+
+func (f *fakeNotaryClient) RemoveKey(ctx context.Context, in *KeyID, opts ...grpc.CallOption) (*Empty, error) {
+	if f.removeKeyFn != nil {
+		return f.removeKeyFn(in)
+	}
+	return &Empty{}, nil
+}
+
+func (f *fakeNotaryClient) ListKeys(ctx context.Context, in *RoleQuery, opts ...grpc.CallOption) (*KeyIDList, error) {
+	if f.listKeysFn != nil {
+		return f.listKeysFn(in)
+	}
+	return &KeyIDList{}, nil
+}
+
+func (f *fakeNotaryClient) ListAllKeys(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*KeyRoleMap, error) {
+	if f.listAllKeysFn != nil {
+		return f.listAllKeysFn(in)
+	}
+	return &KeyRoleMap{}, nil
+}
+
+func (f *fakeNotaryClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	if f.signFn != nil {
+		return f.signFn(in)
+	}
+	return &SignResponse{}, nil
+}
+
+func newTestClient(fake *fakeNotaryClient) *Client {
+	return &Client{
+		client: fake,
+		cs:     &CryptoService{client: fake},
+		gun:    data.GUN("docker.io/library/notary"),
+	}
+}
+
+// TestDelegationRoundTrip covers chunk0-1: add a delegation, confirm it shows
+// up via GetDelegationRoles, then remove it.
+func TestDelegationRoundTrip(t *testing.T) {
+	var added, published, removed bool
+	roles := []*Role{}
+
+	fake := &fakeNotaryClient{
+		addDelegationFn: func(in *DelegationRoleAndKeysAndPaths) (*Empty, error) {
+			if in.Name != "targets/releases" {
+				t.Fatalf("AddDelegation: got name %q", in.Name)
+			}
+			added = true
+			roles = append(roles, &Role{Name: in.Name, RootRole: &RootRole{}, Paths: in.Paths})
+			return &Empty{}, nil
+		},
+		publishFn: func(*Empty) (*Empty, error) {
+			if !added {
+				t.Fatal("Publish: called before AddDelegation")
+			}
+			published = true
+			return &Empty{}, nil
+		},
+		getDelegationRolesFn: func(*Empty) (*RoleList, error) {
+			return &RoleList{Roles: roles}, nil
+		},
+		removeDelegationRoleFn: func(in *DelegationName) (*Empty, error) {
+			if in.Name != "targets/releases" {
+				t.Fatalf("RemoveDelegationRole: got name %q", in.Name)
+			}
+			removed = true
+			roles = nil
+			return &Empty{}, nil
+		},
+	}
+
+	c := newTestClient(fake)
+	key := data.NewPublicKey("ecdsa", []byte("pub"))
+
+	if err := c.AddDelegation("targets/releases", []data.PublicKey{key}, []string{"released/*"}); err != nil {
+		t.Fatalf("AddDelegation: %v", err)
+	}
+	if !added {
+		t.Fatal("AddDelegation: RPC not called")
+	}
+
+	if err := c.Publish(); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if !published {
+		t.Fatal("Publish: RPC not called")
+	}
+
+	got, err := c.GetDelegationRoles()
+	if err != nil {
+		t.Fatalf("GetDelegationRoles: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "targets/releases" {
+		t.Fatalf("GetDelegationRoles: got %+v", got)
+	}
+
+	if err := c.RemoveDelegationRole("targets/releases"); err != nil {
+		t.Fatalf("RemoveDelegationRole: %v", err)
+	}
+	if !removed {
+		t.Fatal("RemoveDelegationRole: RPC not called")
+	}
+
+	got, err = c.GetDelegationRoles()
+	if err != nil {
+		t.Fatalf("GetDelegationRoles after remove: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("GetDelegationRoles after remove: got %+v, want none", got)
+	}
+}
+
+// TestGetTargetsByNameOverlappingPaths covers chunk0-2: when two delegations
+// both claim a path, GetTargetByName returns the first role in precedence
+// order while GetTargetsByName returns every match.
+func TestGetTargetsByNameOverlappingPaths(t *testing.T) {
+	byRole := map[string]*TargetWithRole{
+		"targets/releases": {Target: &Target{Name: "v1.0", Length: 10}, Role: "targets/releases"},
+		"targets":          {Target: &Target{Name: "v1.0", Length: 10}, Role: "targets"},
+	}
+
+	fake := &fakeNotaryClient{
+		getTargetByNameFn: func(in *TargetByNameAction) (*TargetWithRoleResponse, error) {
+			for _, r := range in.Roles.Roles {
+				if t, ok := byRole[r]; ok {
+					return &TargetWithRoleResponse{TargetWithRole: t}, nil
+				}
+			}
+			return nil, status.Error(codes.NotFound, "no match")
+		},
+		getTargetsByNameFn: func(in *TargetByNameAction) (*TargetWithRoleList, error) {
+			var matches []*TargetWithRole
+			for _, r := range in.Roles.Roles {
+				if t, ok := byRole[r]; ok {
+					matches = append(matches, t)
+				}
+			}
+			return &TargetWithRoleList{TargetWithRoleNameList: &TargetWithRoleNameList{Targets: matches}}, nil
+		},
+	}
+
+	c := newTestClient(fake)
+
+	first, err := c.ReleasesTarget("v1.0")
+	if err != nil {
+		t.Fatalf("ReleasesTarget: %v", err)
+	}
+	if first.Role != ReleasesRole {
+		t.Fatalf("ReleasesTarget: got role %s, want %s (releases must win precedence)", first.Role, ReleasesRole)
+	}
+
+	all, err := c.GetTargetsByName("v1.0", ReleasesRole, data.CanonicalTargetsRole)
+	if err != nil {
+		t.Fatalf("GetTargetsByName: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("GetTargetsByName: got %d matches, want 2 (one per overlapping role)", len(all))
+	}
+}
+
+// TestInitializeWithCertificate covers chunk0-3: root certs are translated
+// into proto PublicKeys and forwarded on the InitializeWithCertificate RPC.
+func TestInitializeWithCertificate(t *testing.T) {
+	var gotCerts []*PublicKey
+	fake := &fakeNotaryClient{
+		initializeWithCertificateFn: func(in *InitMessage) (*Empty, error) {
+			gotCerts = in.RootCerts
+			return &Empty{}, nil
+		},
+	}
+
+	c := newTestClient(fake)
+	cert := data.NewPublicKey("rsa-x509", []byte("cert-bytes"))
+
+	if err := c.InitializeWithCertificate([]string{"root-key-1"}, []data.PublicKey{cert}, data.RoleName("timestamp")); err != nil {
+		t.Fatalf("InitializeWithCertificate: %v", err)
+	}
+	if len(gotCerts) != 1 || gotCerts[0].Algorithm != "rsa-x509" || string(gotCerts[0].Public) != "cert-bytes" {
+		t.Fatalf("InitializeWithCertificate: RootCerts not forwarded, got %+v", gotCerts)
+	}
+}
+
+// TestCryptoServiceRemoveKeyNotFound covers chunk0-4: removing a key that
+// doesn't exist on the remote signer must not surface as an error.
+func TestCryptoServiceRemoveKeyNotFound(t *testing.T) {
+	fake := &fakeNotaryClient{
+		removeKeyFn: func(*KeyID) (*Empty, error) {
+			return nil, status.Error(codes.NotFound, "key not found")
+		},
+	}
+	cs := &CryptoService{client: fake}
+
+	if err := cs.RemoveKey("nonexistent"); err != nil {
+		t.Fatalf("RemoveKey on missing key: got error %v, want nil", err)
+	}
+}
+
+// TestCryptoServiceSignRoundTrip covers chunk0-4: a key fetched through
+// GetPrivateKey produces a signature, via the Sign RPC, that verifies
+// against the key's real public half, for both the ECDSA and ED25519 keys
+// CryptoService can hand back.
+func TestCryptoServiceSignRoundTrip(t *testing.T) {
+	payload := []byte("trust me")
+
+	t.Run("ecdsa", func(t *testing.T) {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		pub, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+		if err != nil {
+			t.Fatalf("MarshalPKIXPublicKey: %v", err)
+		}
+		fake := &fakeNotaryClient{
+			getKeyFn: func(*KeyID) (*KeyInfo, error) {
+				return &KeyInfo{Algorithm: "ecdsa", Public: pub}, nil
+			},
+			signFn: func(in *SignRequest) (*SignResponse, error) {
+				sig, err := priv.Sign(rand.Reader, in.Payload, crypto.Hash(0))
+				if err != nil {
+					return nil, err
+				}
+				return &SignResponse{Signature: sig}, nil
+			},
+		}
+		cs := &CryptoService{client: fake}
+
+		signer, _, err := cs.GetPrivateKey("ecdsa-key")
+		if err != nil {
+			t.Fatalf("GetPrivateKey: %v", err)
+		}
+		sig, err := signer.Sign(rand.Reader, payload, crypto.Hash(0))
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		if !ecdsa.VerifyASN1(&priv.PublicKey, payload, sig) {
+			t.Fatal("Sign: signature does not verify against the ECDSA public key")
+		}
+	})
+
+	t.Run("ed25519", func(t *testing.T) {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		pubBytes, err := x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			t.Fatalf("MarshalPKIXPublicKey: %v", err)
+		}
+		fake := &fakeNotaryClient{
+			getKeyFn: func(*KeyID) (*KeyInfo, error) {
+				return &KeyInfo{Algorithm: "ed25519", Public: pubBytes}, nil
+			},
+			signFn: func(in *SignRequest) (*SignResponse, error) {
+				sig, err := priv.Sign(rand.Reader, in.Payload, crypto.Hash(0))
+				if err != nil {
+					return nil, err
+				}
+				return &SignResponse{Signature: sig}, nil
+			},
+		}
+		cs := &CryptoService{client: fake}
+
+		signer, _, err := cs.GetPrivateKey("ed25519-key")
+		if err != nil {
+			t.Fatalf("GetPrivateKey: %v", err)
+		}
+		sig, err := signer.Sign(rand.Reader, payload, crypto.Hash(0))
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		if !ed25519.Verify(pub, payload, sig) {
+			t.Fatal("Sign: signature does not verify against the ED25519 public key")
+		}
+	})
+}
+
+// TestCryptoServiceRemoveKeyOtherError covers the flip side: a real failure
+// (not NotFound) must still be surfaced.
+func TestCryptoServiceRemoveKeyOtherError(t *testing.T) {
+	fake := &fakeNotaryClient{
+		removeKeyFn: func(*KeyID) (*Empty, error) {
+			return nil, status.Error(codes.Unavailable, "signer unreachable")
+		},
+	}
+	cs := &CryptoService{client: fake}
+
+	if err := cs.RemoveKey("some-key"); err == nil {
+		t.Fatal("RemoveKey: got nil error, want the signer's error surfaced")
+	}
+}
+
+// TestRotateKey covers chunk0-5: both server-managed and locally-supplied
+// key rotation forward their arguments untouched.
+func TestRotateKey(t *testing.T) {
+	var got *RotateKeyRequest
+	fake := &fakeNotaryClient{
+		rotateKeyFn: func(in *RotateKeyRequest) (*Empty, error) {
+			got = in
+			return &Empty{}, nil
+		},
+	}
+	c := newTestClient(fake)
+
+	if err := c.RotateKey(data.RoleName("snapshot"), true, nil); err != nil {
+		t.Fatalf("RotateKey (server-managed): %v", err)
+	}
+	if !got.ServerManagesKey || got.Role != "snapshot" {
+		t.Fatalf("RotateKey (server-managed): got %+v", got)
+	}
+
+	if err := c.RotateKey(data.RoleName("targets"), false, []string{"key-1"}); err != nil {
+		t.Fatalf("RotateKey (local key): %v", err)
+	}
+	if got.ServerManagesKey || got.Role != "targets" || len(got.KeyIDs) != 1 || got.KeyIDs[0] != "key-1" {
+		t.Fatalf("RotateKey (local key): got %+v", got)
+	}
+}
+
+// TestRotateKeyRejectsDelegatedRole covers chunk0-5: the server's rejection
+// of a delegated-role rotation surfaces to the caller as an error.
+func TestRotateKeyRejectsDelegatedRole(t *testing.T) {
+	fake := &fakeNotaryClient{
+		rotateKeyFn: func(in *RotateKeyRequest) (*Empty, error) {
+			return nil, status.Error(codes.InvalidArgument, "targets/releases is a delegated role")
+		},
+	}
+	c := newTestClient(fake)
+
+	if err := c.RotateKey(data.RoleName("targets/releases"), false, []string{"key-1"}); err == nil {
+		t.Fatal("RotateKey: got nil error, want the delegated-role rejection surfaced")
+	}
+}
+
+// TestWitness covers chunk0-6: Witness is a thin forwarder over the queued
+// roles client.NotaryRepository.Witness itself reports, across the three
+// scenarios the role is meant for (a parent key rotation, a snapshot
+// expiring, and a role Witness can't queue at all), rather than a
+// fabricated per-role missing-key contract the real repo never produces.
+func TestWitness(t *testing.T) {
+	t.Run("witness after parent key rotation", func(t *testing.T) {
+		fake := &fakeNotaryClient{
+			witnessFn: func(in *WitnessRequest) (*WitnessResponse, error) {
+				if len(in.Roles) != 1 || in.Roles[0] != "targets/releases" {
+					t.Fatalf("Witness: got roles %v", in.Roles)
+				}
+				return &WitnessResponse{WitnessedRoles: []string{"targets/releases"}}, nil
+			},
+		}
+		c := newTestClient(fake)
+
+		witnessed, err := c.Witness(data.RoleName("targets/releases"))
+		if err != nil {
+			t.Fatalf("Witness: %v", err)
+		}
+		if len(witnessed) != 1 || witnessed[0] != data.RoleName("targets/releases") {
+			t.Fatalf("Witness: got %+v", witnessed)
+		}
+	})
+
+	t.Run("witness after snapshot expiry", func(t *testing.T) {
+		fake := &fakeNotaryClient{
+			witnessFn: func(in *WitnessRequest) (*WitnessResponse, error) {
+				if len(in.Roles) != 1 || in.Roles[0] != "snapshot" {
+					t.Fatalf("Witness: got roles %v", in.Roles)
+				}
+				return &WitnessResponse{WitnessedRoles: []string{"snapshot"}}, nil
+			},
+		}
+		c := newTestClient(fake)
+
+		witnessed, err := c.Witness(data.RoleName("snapshot"))
+		if err != nil {
+			t.Fatalf("Witness: %v", err)
+		}
+		if len(witnessed) != 1 || witnessed[0] != data.RoleName("snapshot") {
+			t.Fatalf("Witness: got %+v", witnessed)
+		}
+	})
+
+	t.Run("no local key for the role is a forwarded error, not a partial result", func(t *testing.T) {
+		fake := &fakeNotaryClient{
+			witnessFn: func(in *WitnessRequest) (*WitnessResponse, error) {
+				return nil, status.Error(codes.Unknown, "no valid signing keys for role targets/releases")
+			},
+		}
+		c := newTestClient(fake)
+
+		if _, err := c.Witness(data.RoleName("targets/releases")); err == nil {
+			t.Fatal("Witness: got nil error, want the no-local-key error surfaced")
+		}
+	})
+}
+
+// TestDeleteTrustDataLocalAndRemote covers chunk0-7: local-only delete,
+// local+remote delete, and an unconfirmed local delete.
+func TestDeleteTrustDataLocalAndRemote(t *testing.T) {
+	t.Run("local only", func(t *testing.T) {
+		fake := &fakeNotaryClient{
+			deleteTrustDataFn: func(in *DeleteTrustDataRequest) (*DeleteTrustDataResponse, error) {
+				if in.DeleteRemote {
+					t.Fatal("DeleteTrustData: DeleteRemote set on a local-only request")
+				}
+				return &DeleteTrustDataResponse{LocalDeleted: true}, nil
+			},
+		}
+		c := newTestClient(fake)
+		if err := c.DeleteTrustData(false); err != nil {
+			t.Fatalf("DeleteTrustData(false): %v", err)
+		}
+	})
+
+	t.Run("local and remote", func(t *testing.T) {
+		fake := &fakeNotaryClient{
+			deleteTrustDataFn: func(in *DeleteTrustDataRequest) (*DeleteTrustDataResponse, error) {
+				return &DeleteTrustDataResponse{LocalDeleted: true, RemoteDeleted: true}, nil
+			},
+		}
+		c := newTestClient(fake)
+		if err := c.DeleteTrustData(true); err != nil {
+			t.Fatalf("DeleteTrustData(true): %v", err)
+		}
+	})
+
+	t.Run("local delete not confirmed", func(t *testing.T) {
+		fake := &fakeNotaryClient{
+			deleteTrustDataFn: func(*DeleteTrustDataRequest) (*DeleteTrustDataResponse, error) {
+				return &DeleteTrustDataResponse{}, nil
+			},
+		}
+		c := newTestClient(fake)
+		err := c.DeleteTrustData(false)
+		if _, ok := err.(ErrTrustDataLocalDeleteFailed); !ok {
+			t.Fatalf("DeleteTrustData: got %#v (%T), want ErrTrustDataLocalDeleteFailed", err, err)
+		}
+	})
+
+	t.Run("remote delete not confirmed", func(t *testing.T) {
+		fake := &fakeNotaryClient{
+			deleteTrustDataFn: func(*DeleteTrustDataRequest) (*DeleteTrustDataResponse, error) {
+				return &DeleteTrustDataResponse{LocalDeleted: true}, nil
+			},
+		}
+		c := newTestClient(fake)
+		err := c.DeleteTrustData(true)
+		if _, ok := err.(ErrTrustDataRemoteDeleteFailed); !ok {
+			t.Fatalf("DeleteTrustData: got %#v (%T), want ErrTrustDataRemoteDeleteFailed", err, err)
+		}
+	})
+}
+
+// TestWrapNotaryError covers chunk0-8: every well-known error class
+// round-trips through toStatus and back via wrapNotaryError to its
+// original concrete Go error type.
+func TestWrapNotaryError(t *testing.T) {
+	t.Run("offline", func(t *testing.T) {
+		got := wrapNotaryError(toStatus(storage.ErrOffline{}))
+		if _, ok := got.(storage.ErrOffline); !ok {
+			t.Fatalf("got %#v (%T), want storage.ErrOffline", got, got)
+		}
+	})
+
+	t.Run("repository not exist", func(t *testing.T) {
+		got := wrapNotaryError(toStatus(client.ErrRepositoryNotExist{}))
+		if _, ok := got.(client.ErrRepositoryNotExist); !ok {
+			t.Fatalf("got %#v (%T), want client.ErrRepositoryNotExist", got, got)
+		}
+	})
+
+	t.Run("repo not initialized", func(t *testing.T) {
+		got := wrapNotaryError(toStatus(client.ErrRepoNotInitialized{}))
+		if _, ok := got.(client.ErrRepoNotInitialized); !ok {
+			t.Fatalf("got %#v (%T), want client.ErrRepoNotInitialized", got, got)
+		}
+	})
+
+	t.Run("insufficient signatures", func(t *testing.T) {
+		got := wrapNotaryError(toStatus(signed.ErrInsufficientSignatures{FoundKeys: 1, NeededKeys: 3}))
+		sigErr, ok := got.(signed.ErrInsufficientSignatures)
+		if !ok {
+			t.Fatalf("got %#v (%T), want signed.ErrInsufficientSignatures", got, got)
+		}
+		if sigErr.FoundKeys != 1 || sigErr.NeededKeys != 3 {
+			t.Fatalf("got %+v, want FoundKeys=1 NeededKeys=3", sigErr)
+		}
+	})
+
+	t.Run("unmapped status error passes through unchanged", func(t *testing.T) {
+		plain := status.Error(codes.Internal, "no details here")
+		if got := wrapNotaryError(plain); got != plain {
+			t.Fatalf("got %#v, want the original error unchanged", got)
+		}
+	})
+}
@@ -0,0 +1,768 @@
+// gRPC client and server stubs for the Notary service in api.proto, written
+// by hand in the shape protoc-gen-go-grpc produces, together with the status
+// helpers shared by toStatus and wrapNotaryError.
+
+package api
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"golang.org/x/net/context"
+)
+
+// NotaryClient is the client API for the Notary service.
+type NotaryClient interface {
+	Initialize(ctx context.Context, in *InitMessage, opts ...grpc.CallOption) (*Empty, error)
+	InitializeWithCertificate(ctx context.Context, in *InitMessage, opts ...grpc.CallOption) (*Empty, error)
+	Publish(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	DeleteTrustData(ctx context.Context, in *DeleteTrustDataRequest, opts ...grpc.CallOption) (*DeleteTrustDataResponse, error)
+	AddTarget(ctx context.Context, in *Target, opts ...grpc.CallOption) (*Empty, error)
+	RemoveTarget(ctx context.Context, in *Target, opts ...grpc.CallOption) (*Empty, error)
+	ListTargets(ctx context.Context, in *RoleNameList, opts ...grpc.CallOption) (*TargetWithRoleList, error)
+	GetTargetByName(ctx context.Context, in *TargetByNameAction, opts ...grpc.CallOption) (*TargetWithRoleResponse, error)
+	GetTargetsByName(ctx context.Context, in *TargetByNameAction, opts ...grpc.CallOption) (*TargetWithRoleList, error)
+	GetAllTargetMetadataByName(ctx context.Context, in *TargetName, opts ...grpc.CallOption) (*TargetSignedListResponse, error)
+	GetChangelist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangelistResponse, error)
+	ListRoles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoleWithSignaturesListResponse, error)
+	GetDelegationRoles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoleList, error)
+	AddDelegation(ctx context.Context, in *DelegationRoleAndKeysAndPaths, opts ...grpc.CallOption) (*Empty, error)
+	AddDelegationRoleAndKeys(ctx context.Context, in *DelegationRoleAndKeys, opts ...grpc.CallOption) (*Empty, error)
+	AddDelegationPaths(ctx context.Context, in *DelegationPaths, opts ...grpc.CallOption) (*Empty, error)
+	RemoveDelegationKeysAndPaths(ctx context.Context, in *DelegationKeysAndPaths, opts ...grpc.CallOption) (*Empty, error)
+	RemoveDelegationRole(ctx context.Context, in *DelegationName, opts ...grpc.CallOption) (*Empty, error)
+	RemoveDelegationPaths(ctx context.Context, in *DelegationPaths, opts ...grpc.CallOption) (*Empty, error)
+	RemoveDelegationKeys(ctx context.Context, in *DelegationKeys, opts ...grpc.CallOption) (*Empty, error)
+	ClearDelegationPaths(ctx context.Context, in *DelegationName, opts ...grpc.CallOption) (*Empty, error)
+	Witness(ctx context.Context, in *WitnessRequest, opts ...grpc.CallOption) (*WitnessResponse, error)
+	RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*PublicKey, error)
+	GetKey(ctx context.Context, in *KeyID, opts ...grpc.CallOption) (*KeyInfo, error)
+	RemoveKey(ctx context.Context, in *KeyID, opts ...grpc.CallOption) (*Empty, error)
+	ListKeys(ctx context.Context, in *RoleQuery, opts ...grpc.CallOption) (*KeyIDList, error)
+	ListAllKeys(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*KeyRoleMap, error)
+	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+}
+
+type notaryClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewNotaryClient wraps conn in the NotaryClient stub.
+func NewNotaryClient(cc *grpc.ClientConn) NotaryClient {
+	return &notaryClient{cc: cc}
+}
+
+func (c *notaryClient) call(ctx context.Context, method string, in, out interface{}, opts ...grpc.CallOption) error {
+	return c.cc.Invoke(ctx, method, in, out, opts...)
+}
+
+func (c *notaryClient) Initialize(ctx context.Context, in *InitMessage, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/Initialize", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) InitializeWithCertificate(ctx context.Context, in *InitMessage, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/InitializeWithCertificate", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) Publish(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/Publish", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) DeleteTrustData(ctx context.Context, in *DeleteTrustDataRequest, opts ...grpc.CallOption) (*DeleteTrustDataResponse, error) {
+	out := new(DeleteTrustDataResponse)
+	err := c.call(ctx, "/api.Notary/DeleteTrustData", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) AddTarget(ctx context.Context, in *Target, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/AddTarget", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) RemoveTarget(ctx context.Context, in *Target, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/RemoveTarget", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) ListTargets(ctx context.Context, in *RoleNameList, opts ...grpc.CallOption) (*TargetWithRoleList, error) {
+	out := new(TargetWithRoleList)
+	err := c.call(ctx, "/api.Notary/ListTargets", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) GetTargetByName(ctx context.Context, in *TargetByNameAction, opts ...grpc.CallOption) (*TargetWithRoleResponse, error) {
+	out := new(TargetWithRoleResponse)
+	err := c.call(ctx, "/api.Notary/GetTargetByName", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) GetTargetsByName(ctx context.Context, in *TargetByNameAction, opts ...grpc.CallOption) (*TargetWithRoleList, error) {
+	out := new(TargetWithRoleList)
+	err := c.call(ctx, "/api.Notary/GetTargetsByName", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) GetAllTargetMetadataByName(ctx context.Context, in *TargetName, opts ...grpc.CallOption) (*TargetSignedListResponse, error) {
+	out := new(TargetSignedListResponse)
+	err := c.call(ctx, "/api.Notary/GetAllTargetMetadataByName", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) GetChangelist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangelistResponse, error) {
+	out := new(ChangelistResponse)
+	err := c.call(ctx, "/api.Notary/GetChangelist", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) ListRoles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoleWithSignaturesListResponse, error) {
+	out := new(RoleWithSignaturesListResponse)
+	err := c.call(ctx, "/api.Notary/ListRoles", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) GetDelegationRoles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RoleList, error) {
+	out := new(RoleList)
+	err := c.call(ctx, "/api.Notary/GetDelegationRoles", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) AddDelegation(ctx context.Context, in *DelegationRoleAndKeysAndPaths, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/AddDelegation", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) AddDelegationRoleAndKeys(ctx context.Context, in *DelegationRoleAndKeys, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/AddDelegationRoleAndKeys", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) AddDelegationPaths(ctx context.Context, in *DelegationPaths, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/AddDelegationPaths", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) RemoveDelegationKeysAndPaths(ctx context.Context, in *DelegationKeysAndPaths, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/RemoveDelegationKeysAndPaths", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) RemoveDelegationRole(ctx context.Context, in *DelegationName, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/RemoveDelegationRole", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) RemoveDelegationPaths(ctx context.Context, in *DelegationPaths, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/RemoveDelegationPaths", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) RemoveDelegationKeys(ctx context.Context, in *DelegationKeys, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/RemoveDelegationKeys", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) ClearDelegationPaths(ctx context.Context, in *DelegationName, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/ClearDelegationPaths", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) Witness(ctx context.Context, in *WitnessRequest, opts ...grpc.CallOption) (*WitnessResponse, error) {
+	out := new(WitnessResponse)
+	err := c.call(ctx, "/api.Notary/Witness", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/RotateKey", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*PublicKey, error) {
+	out := new(PublicKey)
+	err := c.call(ctx, "/api.Notary/CreateKey", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) GetKey(ctx context.Context, in *KeyID, opts ...grpc.CallOption) (*KeyInfo, error) {
+	out := new(KeyInfo)
+	err := c.call(ctx, "/api.Notary/GetKey", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) RemoveKey(ctx context.Context, in *KeyID, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.call(ctx, "/api.Notary/RemoveKey", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) ListKeys(ctx context.Context, in *RoleQuery, opts ...grpc.CallOption) (*KeyIDList, error) {
+	out := new(KeyIDList)
+	err := c.call(ctx, "/api.Notary/ListKeys", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) ListAllKeys(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*KeyRoleMap, error) {
+	out := new(KeyRoleMap)
+	err := c.call(ctx, "/api.Notary/ListAllKeys", in, out, opts...)
+	return out, err
+}
+
+func (c *notaryClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.call(ctx, "/api.Notary/Sign", in, out, opts...)
+	return out, err
+}
+
+// NotaryServer is the server API for the Notary service.
+type NotaryServer interface {
+	Initialize(context.Context, *InitMessage) (*Empty, error)
+	InitializeWithCertificate(context.Context, *InitMessage) (*Empty, error)
+	Publish(context.Context, *Empty) (*Empty, error)
+	DeleteTrustData(context.Context, *DeleteTrustDataRequest) (*DeleteTrustDataResponse, error)
+	AddTarget(context.Context, *Target) (*Empty, error)
+	RemoveTarget(context.Context, *Target) (*Empty, error)
+	ListTargets(context.Context, *RoleNameList) (*TargetWithRoleList, error)
+	GetTargetByName(context.Context, *TargetByNameAction) (*TargetWithRoleResponse, error)
+	GetTargetsByName(context.Context, *TargetByNameAction) (*TargetWithRoleList, error)
+	GetAllTargetMetadataByName(context.Context, *TargetName) (*TargetSignedListResponse, error)
+	GetChangelist(context.Context, *Empty) (*ChangelistResponse, error)
+	ListRoles(context.Context, *Empty) (*RoleWithSignaturesListResponse, error)
+	GetDelegationRoles(context.Context, *Empty) (*RoleList, error)
+	AddDelegation(context.Context, *DelegationRoleAndKeysAndPaths) (*Empty, error)
+	AddDelegationRoleAndKeys(context.Context, *DelegationRoleAndKeys) (*Empty, error)
+	AddDelegationPaths(context.Context, *DelegationPaths) (*Empty, error)
+	RemoveDelegationKeysAndPaths(context.Context, *DelegationKeysAndPaths) (*Empty, error)
+	RemoveDelegationRole(context.Context, *DelegationName) (*Empty, error)
+	RemoveDelegationPaths(context.Context, *DelegationPaths) (*Empty, error)
+	RemoveDelegationKeys(context.Context, *DelegationKeys) (*Empty, error)
+	ClearDelegationPaths(context.Context, *DelegationName) (*Empty, error)
+	Witness(context.Context, *WitnessRequest) (*WitnessResponse, error)
+	RotateKey(context.Context, *RotateKeyRequest) (*Empty, error)
+	CreateKey(context.Context, *CreateKeyRequest) (*PublicKey, error)
+	GetKey(context.Context, *KeyID) (*KeyInfo, error)
+	RemoveKey(context.Context, *KeyID) (*Empty, error)
+	ListKeys(context.Context, *RoleQuery) (*KeyIDList, error)
+	ListAllKeys(context.Context, *Empty) (*KeyRoleMap, error)
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+}
+
+// notFound builds a gRPC NotFound status from a format string, used by
+// handlers that need to signal "no such key/role/target" distinctly from
+// a generic failure.
+func notFound(format string, args ...interface{}) error {
+	return status.Errorf(codes.NotFound, format, args...)
+}
+
+// These constants pair a gRPC code with an exact (or, for
+// insufficientSignaturesFormat, parseable) status message, giving toStatus
+// and wrapNotaryError a way to round-trip the handful of well-known notary
+// error classes across the wire without a proto-encoded status detail: a
+// plain codes.Code plus a message is ordinary gRPC, unlike status.WithDetails,
+// which requires an actual protoc-generated proto.Message.
+const (
+	offlineMessage               = "remote is offline"
+	repositoryNotExistMessage    = "repository does not exist"
+	repoNotInitializedMessage    = "repository not initialized"
+	insufficientSignaturesFormat = "insufficient signatures: found %d of %d required"
+)
+
+func _Notary_Initialize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).Initialize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/Initialize"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).Initialize(ctx, req.(*InitMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_InitializeWithCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).InitializeWithCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/InitializeWithCertificate"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).InitializeWithCertificate(ctx, req.(*InitMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/Publish"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).Publish(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_DeleteTrustData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTrustDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).DeleteTrustData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/DeleteTrustData"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).DeleteTrustData(ctx, req.(*DeleteTrustDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_AddTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Target)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).AddTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/AddTarget"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).AddTarget(ctx, req.(*Target))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_RemoveTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Target)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).RemoveTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/RemoveTarget"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).RemoveTarget(ctx, req.(*Target))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_ListTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleNameList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).ListTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/ListTargets"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).ListTargets(ctx, req.(*RoleNameList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_GetTargetByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TargetByNameAction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).GetTargetByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/GetTargetByName"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).GetTargetByName(ctx, req.(*TargetByNameAction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_GetTargetsByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TargetByNameAction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).GetTargetsByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/GetTargetsByName"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).GetTargetsByName(ctx, req.(*TargetByNameAction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_GetAllTargetMetadataByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TargetName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).GetAllTargetMetadataByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/GetAllTargetMetadataByName"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).GetAllTargetMetadataByName(ctx, req.(*TargetName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_GetChangelist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).GetChangelist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/GetChangelist"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).GetChangelist(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_ListRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).ListRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/ListRoles"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).ListRoles(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_GetDelegationRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).GetDelegationRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/GetDelegationRoles"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).GetDelegationRoles(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_AddDelegation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationRoleAndKeysAndPaths)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).AddDelegation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/AddDelegation"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).AddDelegation(ctx, req.(*DelegationRoleAndKeysAndPaths))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_AddDelegationRoleAndKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationRoleAndKeys)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).AddDelegationRoleAndKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/AddDelegationRoleAndKeys"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).AddDelegationRoleAndKeys(ctx, req.(*DelegationRoleAndKeys))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_AddDelegationPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationPaths)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).AddDelegationPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/AddDelegationPaths"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).AddDelegationPaths(ctx, req.(*DelegationPaths))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_RemoveDelegationKeysAndPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationKeysAndPaths)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).RemoveDelegationKeysAndPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/RemoveDelegationKeysAndPaths"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).RemoveDelegationKeysAndPaths(ctx, req.(*DelegationKeysAndPaths))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_RemoveDelegationRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).RemoveDelegationRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/RemoveDelegationRole"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).RemoveDelegationRole(ctx, req.(*DelegationName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_RemoveDelegationPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationPaths)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).RemoveDelegationPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/RemoveDelegationPaths"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).RemoveDelegationPaths(ctx, req.(*DelegationPaths))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_RemoveDelegationKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationKeys)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).RemoveDelegationKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/RemoveDelegationKeys"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).RemoveDelegationKeys(ctx, req.(*DelegationKeys))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_ClearDelegationPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).ClearDelegationPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/ClearDelegationPaths"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).ClearDelegationPaths(ctx, req.(*DelegationName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_Witness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WitnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).Witness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/Witness"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).Witness(ctx, req.(*WitnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/RotateKey"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).RotateKey(ctx, req.(*RotateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_CreateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).CreateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/CreateKey"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).CreateKey(ctx, req.(*CreateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_GetKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).GetKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/GetKey"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).GetKey(ctx, req.(*KeyID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_RemoveKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).RemoveKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/RemoveKey"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).RemoveKey(ctx, req.(*KeyID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_ListKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).ListKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/ListKeys"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).ListKeys(ctx, req.(*RoleQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_ListAllKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).ListAllKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/ListAllKeys"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).ListAllKeys(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notary_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotaryServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/api.Notary/Sign"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotaryServer).Sign(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Notary_ServiceDesc is the grpc.ServiceDesc for the Notary service. It is
+// used by RegisterNotaryServer; the client stub invokes methods by their
+// full names and does not use it.
+var Notary_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Notary",
+	HandlerType: (*NotaryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Initialize", Handler: _Notary_Initialize_Handler},
+		{MethodName: "InitializeWithCertificate", Handler: _Notary_InitializeWithCertificate_Handler},
+		{MethodName: "Publish", Handler: _Notary_Publish_Handler},
+		{MethodName: "DeleteTrustData", Handler: _Notary_DeleteTrustData_Handler},
+		{MethodName: "AddTarget", Handler: _Notary_AddTarget_Handler},
+		{MethodName: "RemoveTarget", Handler: _Notary_RemoveTarget_Handler},
+		{MethodName: "ListTargets", Handler: _Notary_ListTargets_Handler},
+		{MethodName: "GetTargetByName", Handler: _Notary_GetTargetByName_Handler},
+		{MethodName: "GetTargetsByName", Handler: _Notary_GetTargetsByName_Handler},
+		{MethodName: "GetAllTargetMetadataByName", Handler: _Notary_GetAllTargetMetadataByName_Handler},
+		{MethodName: "GetChangelist", Handler: _Notary_GetChangelist_Handler},
+		{MethodName: "ListRoles", Handler: _Notary_ListRoles_Handler},
+		{MethodName: "GetDelegationRoles", Handler: _Notary_GetDelegationRoles_Handler},
+		{MethodName: "AddDelegation", Handler: _Notary_AddDelegation_Handler},
+		{MethodName: "AddDelegationRoleAndKeys", Handler: _Notary_AddDelegationRoleAndKeys_Handler},
+		{MethodName: "AddDelegationPaths", Handler: _Notary_AddDelegationPaths_Handler},
+		{MethodName: "RemoveDelegationKeysAndPaths", Handler: _Notary_RemoveDelegationKeysAndPaths_Handler},
+		{MethodName: "RemoveDelegationRole", Handler: _Notary_RemoveDelegationRole_Handler},
+		{MethodName: "RemoveDelegationPaths", Handler: _Notary_RemoveDelegationPaths_Handler},
+		{MethodName: "RemoveDelegationKeys", Handler: _Notary_RemoveDelegationKeys_Handler},
+		{MethodName: "ClearDelegationPaths", Handler: _Notary_ClearDelegationPaths_Handler},
+		{MethodName: "Witness", Handler: _Notary_Witness_Handler},
+		{MethodName: "RotateKey", Handler: _Notary_RotateKey_Handler},
+		{MethodName: "CreateKey", Handler: _Notary_CreateKey_Handler},
+		{MethodName: "GetKey", Handler: _Notary_GetKey_Handler},
+		{MethodName: "RemoveKey", Handler: _Notary_RemoveKey_Handler},
+		{MethodName: "ListKeys", Handler: _Notary_ListKeys_Handler},
+		{MethodName: "ListAllKeys", Handler: _Notary_ListAllKeys_Handler},
+		{MethodName: "Sign", Handler: _Notary_Sign_Handler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api.proto",
+}
+
+// RegisterNotaryServer registers srv as the implementation of the Notary
+// service on s.
+func RegisterNotaryServer(s *grpc.Server, srv NotaryServer) {
+	s.RegisterService(&Notary_ServiceDesc, srv)
+}
@@ -0,0 +1,541 @@
+package api
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/docker/notary/client"
+	"github.com/docker/notary/client/changelist"
+	"github.com/docker/notary/storage"
+	"github.com/docker/notary/tuf/data"
+	"github.com/docker/notary/tuf/signed"
+	"golang.org/x/net/context"
+)
+
+// Repository is the subset of client.NotaryRepository that Server calls
+// through to. It is satisfied structurally by *client.NotaryRepository; it
+// exists here only so Server can be tested against a fake.
+type Repository interface {
+	Initialize(rootKeyIDs []string, serverManagedRoles ...data.RoleName) error
+	InitializeWithCertificate(rootKeyIDs []string, rootCerts []data.PublicKey, serverManagedRoles ...data.RoleName) error
+	Publish() error
+	AddTarget(target *client.Target, roles ...data.RoleName) error
+	RemoveTarget(targetName string, roles ...data.RoleName) error
+	ListTargets(roles ...data.RoleName) ([]*client.TargetWithRole, error)
+	GetTargetByName(name string, roles ...data.RoleName) (*client.TargetWithRole, error)
+	GetAllTargetMetadataByName(name string) ([]client.TargetSignedStruct, error)
+	GetChangelist() (changelist.Changelist, error)
+	ListRoles() ([]client.RoleWithSignatures, error)
+	GetDelegationRoles() ([]data.Role, error)
+	AddDelegation(name data.RoleName, delegationKeys []data.PublicKey, paths []string) error
+	AddDelegationRoleAndKeys(name data.RoleName, delegationKeys []data.PublicKey) error
+	AddDelegationPaths(name data.RoleName, paths []string) error
+	RemoveDelegationKeysAndPaths(name data.RoleName, keyIDs, paths []string) error
+	RemoveDelegationRole(name data.RoleName) error
+	RemoveDelegationPaths(name data.RoleName, paths []string) error
+	RemoveDelegationKeys(name data.RoleName, keyIDs []string) error
+	ClearDelegationPaths(name data.RoleName) error
+	Witness(roles ...data.RoleName) ([]data.RoleName, error)
+	RotateKey(role data.RoleName, serverManagesKey bool, keyList []string) error
+	CryptoService() signed.CryptoService
+	GetGUN() data.GUN
+}
+
+// Server is the NotaryServer implementation that backs Client over gRPC. It
+// is scoped to a single GUN, the same way Repository (and so Client) is:
+// one Server is constructed per repo the process serves, keyed externally
+// by whatever the transport uses to pick a listener/target (e.g. one gRPC
+// server per gun, or a wrapping dispatcher keyed on metadata).
+type Server struct {
+	repo Repository
+
+	// trustDir and remoteURL are the local trust cache directory and
+	// remote notary-server URL that repo was constructed against. They are
+	// needed directly because client.DeleteTrustData is a package-level
+	// function, not a Repository method.
+	trustDir  string
+	remoteURL string
+}
+
+// NewServer returns a Server that dispatches RPCs to repo. trustDir and
+// remoteURL must match the values repo itself was constructed with, since
+// they're passed straight through to client.DeleteTrustData.
+func NewServer(repo Repository, trustDir, remoteURL string) *Server {
+	return &Server{repo: repo, trustDir: trustDir, remoteURL: remoteURL}
+}
+
+func (s *Server) Initialize(ctx context.Context, in *InitMessage) (*Empty, error) {
+	roles := roleNamesFromStrings(in.GetServerManagedRoles().GetRoles())
+	if err := s.repo.Initialize(in.RootKeyIDs, roles...); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) InitializeWithCertificate(ctx context.Context, in *InitMessage) (*Empty, error) {
+	roles := roleNamesFromStrings(in.GetServerManagedRoles().GetRoles())
+	certs := make([]data.PublicKey, len(in.RootCerts))
+	for i, c := range in.RootCerts {
+		certs[i] = data.NewPublicKey(c.Algorithm, c.Public)
+	}
+	if err := s.repo.InitializeWithCertificate(in.RootKeyIDs, certs, roles...); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) Publish(ctx context.Context, in *Empty) (*Empty, error) {
+	if err := s.repo.Publish(); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+// DeleteTrustData wipes the local TUF cache first, unconditionally, so a
+// remote-delete failure can never be mistaken for "nothing happened". Only
+// once the local wipe is confirmed does it attempt the remote tombstone, so
+// the response can distinguish "local deleted, remote unreachable" from
+// "both deleted" instead of collapsing both into a single error.
+func (s *Server) DeleteTrustData(ctx context.Context, in *DeleteTrustDataRequest) (*DeleteTrustDataResponse, error) {
+	gun := data.GUN(in.Gun)
+	if err := client.DeleteTrustData(s.trustDir, gun, s.remoteURL, nil, false); err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &DeleteTrustDataResponse{LocalDeleted: true}
+	if !in.DeleteRemote {
+		return resp, nil
+	}
+
+	if err := client.DeleteTrustData(s.trustDir, gun, s.remoteURL, nil, true); err != nil {
+		return resp, nil
+	}
+	resp.RemoteDeleted = true
+	return resp, nil
+}
+
+func (s *Server) AddTarget(ctx context.Context, in *Target) (*Empty, error) {
+	t := &client.Target{
+		Name:   in.Name,
+		Hashes: data.Hashes(in.Hashes),
+		Length: in.Length,
+	}
+	if err := s.repo.AddTarget(t); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) RemoveTarget(ctx context.Context, in *Target) (*Empty, error) {
+	if err := s.repo.RemoveTarget(in.Name); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) ListTargets(ctx context.Context, in *RoleNameList) (*TargetWithRoleList, error) {
+	roles := roleNamesFromStrings(in.GetRoles())
+	targets, err := s.repo.ListTargets(roles...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &TargetWithRoleList{TargetWithRoleNameList: &TargetWithRoleNameList{Targets: targetsWithRoleToProto(targets)}}, nil
+}
+
+func (s *Server) GetTargetByName(ctx context.Context, in *TargetByNameAction) (*TargetWithRoleResponse, error) {
+	roles := roleNamesFromStrings(in.GetRoles().GetRoles())
+	target, err := s.repo.GetTargetByName(in.Name, roles...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &TargetWithRoleResponse{TargetWithRole: targetWithRoleToProto(target)}, nil
+}
+
+// GetTargetsByName is served by repeated GetTargetByName lookups, one per
+// role (and, for each role, one per sub-delegation beneath it), since
+// Repository (like client.NotaryRepository) has no bulk equivalent. An empty
+// role list searches the default targets tree, matching GetTargetByName's
+// own default. A role with no matching target is skipped; any other error
+// aborts the whole call rather than being silently dropped. A match from a
+// role that has already contributed one is not listed again.
+func (s *Server) GetTargetsByName(ctx context.Context, in *TargetByNameAction) (*TargetWithRoleList, error) {
+	roles := roleNamesFromStrings(in.GetRoles().GetRoles())
+	if len(roles) == 0 {
+		roles = []data.RoleName{data.CanonicalTargetsRole}
+	}
+
+	var matches []*TargetWithRole
+	seen := make(map[data.RoleName]bool)
+	for _, role := range s.expandToDelegationDescendants(roles) {
+		target, err := s.repo.GetTargetByName(in.Name, role)
+		if err != nil {
+			var noSuchTarget client.ErrNoSuchTarget
+			if errors.As(err, &noSuchTarget) {
+				continue
+			}
+			return nil, toStatus(err)
+		}
+		if seen[target.Role] {
+			continue
+		}
+		seen[target.Role] = true
+		matches = append(matches, targetWithRoleToProto(target))
+	}
+	return &TargetWithRoleList{TargetWithRoleNameList: &TargetWithRoleNameList{Targets: matches}}, nil
+}
+
+// expandToDelegationDescendants appends, for each role in roles, every
+// delegation whose name is a descendant of that role (targets/releases is a
+// descendant of targets, targets/releases/ci is a descendant of both), so a
+// caller asking for "targets" also gets hits from sub-delegations nested
+// underneath it instead of only the exact role named. Roles that aren't
+// ancestors of any delegation are passed through unchanged. If the
+// delegation list can't be fetched, it falls back to the roles as given.
+func (s *Server) expandToDelegationDescendants(roles []data.RoleName) []data.RoleName {
+	delegations, err := s.repo.GetDelegationRoles()
+	if err != nil {
+		return roles
+	}
+
+	out := append([]data.RoleName{}, roles...)
+	for _, role := range roles {
+		prefix := role.String() + "/"
+		for _, d := range delegations {
+			if strings.HasPrefix(d.Name.String(), prefix) {
+				out = append(out, d.Name)
+			}
+		}
+	}
+	return out
+}
+
+func (s *Server) GetAllTargetMetadataByName(ctx context.Context, in *TargetName) (*TargetSignedListResponse, error) {
+	signedTargets, err := s.repo.GetAllTargetMetadataByName(in.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	out := make([]*TargetSigned, len(signedTargets))
+	for i, ts := range signedTargets {
+		keys := make(map[string]*PublicKey, len(ts.Role.Keys))
+		for id, key := range ts.Role.Keys {
+			keys[id] = &PublicKey{Algorithm: key.Algorithm(), Public: key.Public()}
+		}
+
+		sigs := make([]*Signature, len(ts.Signatures))
+		for j, sig := range ts.Signatures {
+			sigs[j] = &Signature{
+				KeyID:     sig.KeyID,
+				Method:    string(sig.Method),
+				Signature: sig.Signature,
+				IsValid:   sig.IsValid,
+			}
+		}
+
+		out[i] = &TargetSigned{
+			Target: &Target{
+				Name:   ts.Target.Name,
+				Length: ts.Target.Length,
+				Hashes: ts.Target.Hashes,
+			},
+			Role: &DelegationRootRole{
+				Keys:      keys,
+				Name:      ts.Role.Name.String(),
+				Threshold: int64(ts.Role.Threshold),
+				Paths:     ts.Role.Paths,
+			},
+			Signatures: sigs,
+		}
+	}
+
+	return &TargetSignedListResponse{TargetSignedList: &TargetSignedList{Targets: out}}, nil
+}
+
+func (s *Server) GetChangelist(ctx context.Context, in *Empty) (*ChangelistResponse, error) {
+	cl, err := s.repo.GetChangelist()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	changes := cl.List()
+	out := make([]*Change, len(changes))
+	for i, c := range changes {
+		out[i] = &Change{
+			Action:  c.Action(),
+			Scope:   c.Scope().String(),
+			Type:    c.Type(),
+			Path:    c.Path(),
+			Content: c.Content(),
+		}
+	}
+
+	return &ChangelistResponse{Changelist: &Changelist{Changes: out}}, nil
+}
+
+func (s *Server) ListRoles(ctx context.Context, in *Empty) (*RoleWithSignaturesListResponse, error) {
+	roles, err := s.repo.ListRoles()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	out := make([]*RoleWithSignatures, len(roles))
+	for i, r := range roles {
+		out[i] = &RoleWithSignatures{
+			Role:       roleToProto(r.Role),
+			Signatures: signaturesToProto(r.Signatures),
+		}
+	}
+
+	return &RoleWithSignaturesListResponse{RoleWithSignaturesList: &RoleWithSignaturesList{RoleWithSignatures: out}}, nil
+}
+
+func (s *Server) GetDelegationRoles(ctx context.Context, in *Empty) (*RoleList, error) {
+	roles, err := s.repo.GetDelegationRoles()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	out := make([]*Role, len(roles))
+	for i, r := range roles {
+		out[i] = roleToProto(r)
+	}
+
+	return &RoleList{Roles: out}, nil
+}
+
+func (s *Server) AddDelegation(ctx context.Context, in *DelegationRoleAndKeysAndPaths) (*Empty, error) {
+	keys, err := protoToPublicKeys(in.Keys)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.repo.AddDelegation(data.RoleName(in.Name), keys, in.Paths); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) AddDelegationRoleAndKeys(ctx context.Context, in *DelegationRoleAndKeys) (*Empty, error) {
+	keys, err := protoToPublicKeys(in.Keys)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.repo.AddDelegationRoleAndKeys(data.RoleName(in.Name), keys); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) AddDelegationPaths(ctx context.Context, in *DelegationPaths) (*Empty, error) {
+	if err := s.repo.AddDelegationPaths(data.RoleName(in.Name), in.Paths); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) RemoveDelegationKeysAndPaths(ctx context.Context, in *DelegationKeysAndPaths) (*Empty, error) {
+	if err := s.repo.RemoveDelegationKeysAndPaths(data.RoleName(in.Name), in.KeyIDs, in.Paths); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) RemoveDelegationRole(ctx context.Context, in *DelegationName) (*Empty, error) {
+	if err := s.repo.RemoveDelegationRole(data.RoleName(in.Name)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) RemoveDelegationPaths(ctx context.Context, in *DelegationPaths) (*Empty, error) {
+	if err := s.repo.RemoveDelegationPaths(data.RoleName(in.Name), in.Paths); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) RemoveDelegationKeys(ctx context.Context, in *DelegationKeys) (*Empty, error) {
+	if err := s.repo.RemoveDelegationKeys(data.RoleName(in.Name), in.KeyIDs); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) ClearDelegationPaths(ctx context.Context, in *DelegationName) (*Empty, error) {
+	if err := s.repo.ClearDelegationPaths(data.RoleName(in.Name)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) Witness(ctx context.Context, in *WitnessRequest) (*WitnessResponse, error) {
+	roles := roleNamesFromStrings(in.Roles)
+	witnessed, err := s.repo.Witness(roles...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &WitnessResponse{WitnessedRoles: roleNamesToStrings(witnessed)}, nil
+}
+
+func (s *Server) RotateKey(ctx context.Context, in *RotateKeyRequest) (*Empty, error) {
+	role := data.RoleName(in.Role)
+	if isDelegatedRole(role) {
+		return nil, status.Errorf(codes.InvalidArgument, "RotateKey: %s is a delegated role; use AddDelegationRoleAndKeys instead", in.Role)
+	}
+	if err := s.repo.RotateKey(role, in.ServerManagesKey, in.KeyIDs); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+// isDelegatedRole reports whether role is a delegated role (e.g.
+// targets/releases) rather than one of the top-level roles (root, targets,
+// snapshot, timestamp), which are never namespaced with a "/".
+func isDelegatedRole(role data.RoleName) bool {
+	return strings.Contains(role.String(), "/")
+}
+
+func (s *Server) CreateKey(ctx context.Context, in *CreateKeyRequest) (*PublicKey, error) {
+	pub, err := s.repo.CryptoService().Create(data.RoleName(in.Role), data.GUN(in.Gun), in.Algorithm)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &PublicKey{Algorithm: pub.Algorithm(), Public: pub.Public()}, nil
+}
+
+func (s *Server) GetKey(ctx context.Context, in *KeyID) (*KeyInfo, error) {
+	cs := s.repo.CryptoService()
+	pub := cs.GetKey(in.KeyID)
+	if pub == nil {
+		return nil, notFound("key %s not found", in.KeyID)
+	}
+	role := cs.ListAllKeys()[in.KeyID]
+	return &KeyInfo{Algorithm: pub.Algorithm(), Public: pub.Public(), Role: role.String()}, nil
+}
+
+func (s *Server) RemoveKey(ctx context.Context, in *KeyID) (*Empty, error) {
+	if err := s.repo.CryptoService().RemoveKey(in.KeyID); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) ListKeys(ctx context.Context, in *RoleQuery) (*KeyIDList, error) {
+	return &KeyIDList{KeyIDs: s.repo.CryptoService().ListKeys(data.RoleName(in.Role))}, nil
+}
+
+func (s *Server) ListAllKeys(ctx context.Context, in *Empty) (*KeyRoleMap, error) {
+	all := s.repo.CryptoService().ListAllKeys()
+	entries := make([]*KeyRoleEntry, 0, len(all))
+	for keyID, role := range all {
+		entries = append(entries, &KeyRoleEntry{KeyID: keyID, Role: role.String()})
+	}
+	return &KeyRoleMap{Entries: entries}, nil
+}
+
+func (s *Server) Sign(ctx context.Context, in *SignRequest) (*SignResponse, error) {
+	priv, _, err := s.repo.CryptoService().GetPrivateKey(in.KeyID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	sig, err := priv.Sign(rand.Reader, in.Payload, crypto.Hash(0))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &SignResponse{Signature: sig}, nil
+}
+
+func roleNamesFromStrings(roles []string) []data.RoleName {
+	out := make([]data.RoleName, len(roles))
+	for i, r := range roles {
+		out[i] = data.RoleName(r)
+	}
+	return out
+}
+
+func roleNamesToStrings(roles []data.RoleName) []string {
+	out := make([]string, len(roles))
+	for i, r := range roles {
+		out[i] = r.String()
+	}
+	return out
+}
+
+func protoToPublicKeys(keys []*PublicKey) ([]data.PublicKey, error) {
+	out := make([]data.PublicKey, len(keys))
+	for i, k := range keys {
+		out[i] = data.NewPublicKey(k.Algorithm, k.Public)
+	}
+	return out, nil
+}
+
+func targetWithRoleToProto(t *client.TargetWithRole) *TargetWithRole {
+	return &TargetWithRole{
+		Target: &Target{
+			Name:   t.Name,
+			Length: t.Length,
+			Hashes: t.Hashes,
+		},
+		Role: t.Role.String(),
+	}
+}
+
+func targetsWithRoleToProto(targets []*client.TargetWithRole) []*TargetWithRole {
+	out := make([]*TargetWithRole, len(targets))
+	for i, t := range targets {
+		out[i] = targetWithRoleToProto(t)
+	}
+	return out
+}
+
+func roleToProto(r data.Role) *Role {
+	return &Role{
+		Name: r.Name.String(),
+		RootRole: &RootRole{
+			KeyIDs:    r.RootRole.KeyIDs,
+			Threshold: int64(r.RootRole.Threshold),
+		},
+		Paths: r.Paths,
+	}
+}
+
+func signaturesToProto(sigs []data.Signature) []*Signature {
+	out := make([]*Signature, len(sigs))
+	for i, sig := range sigs {
+		out[i] = &Signature{
+			KeyID:     sig.KeyID,
+			Method:    string(sig.Method),
+			Signature: sig.Signature,
+			IsValid:   sig.IsValid,
+		}
+	}
+	return out
+}
+
+// toStatus maps the well-known notary error classes to a gRPC code plus a
+// fixed status message, so the client's wrapNotaryError can reconstruct the
+// concrete Go error type on the other side of the wire. Errors that don't
+// match one of those classes are passed through as a plain codes.Unknown
+// status carrying the original message.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch e := err.(type) {
+	case storage.ErrOffline:
+		return status.Error(codes.Unavailable, offlineMessage)
+	case client.ErrRepositoryNotExist:
+		return status.Error(codes.NotFound, repositoryNotExistMessage)
+	case client.ErrRepoNotInitialized:
+		return status.Error(codes.FailedPrecondition, repoNotInitializedMessage)
+	case signed.ErrInsufficientSignatures:
+		return status.Errorf(codes.FailedPrecondition, insufficientSignaturesFormat, e.FoundKeys, e.NeededKeys)
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
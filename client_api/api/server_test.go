@@ -0,0 +1,379 @@
+package api
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/docker/notary/client"
+	"github.com/docker/notary/client/changelist"
+	"github.com/docker/notary/storage"
+	"github.com/docker/notary/tuf/data"
+	"github.com/docker/notary/tuf/signed"
+	"golang.org/x/net/context"
+)
+
+// fakeRepository is a Repository whose behavior is supplied per-test via the
+// function fields below; unset fields return a zero-value response and a
+// nil error.
+type fakeRepository struct {
+	rotateKeyFn          func(role data.RoleName, serverManagesKey bool, keyList []string) error
+	witnessFn            func(roles ...data.RoleName) ([]data.RoleName, error)
+	getTargetByNameFn    func(name string, roles ...data.RoleName) (*client.TargetWithRole, error)
+	getDelegationRolesFn func() ([]data.Role, error)
+	cryptoService        signed.CryptoService
+}
+
+func (f *fakeRepository) Initialize(rootKeyIDs []string, serverManagedRoles ...data.RoleName) error {
+	return nil
+}
+func (f *fakeRepository) InitializeWithCertificate(rootKeyIDs []string, rootCerts []data.PublicKey, serverManagedRoles ...data.RoleName) error {
+	return nil
+}
+func (f *fakeRepository) Publish() error { return nil }
+func (f *fakeRepository) AddTarget(target *client.Target, roles ...data.RoleName) error {
+	return nil
+}
+func (f *fakeRepository) RemoveTarget(targetName string, roles ...data.RoleName) error {
+	return nil
+}
+func (f *fakeRepository) ListTargets(roles ...data.RoleName) ([]*client.TargetWithRole, error) {
+	return nil, nil
+}
+func (f *fakeRepository) GetTargetByName(name string, roles ...data.RoleName) (*client.TargetWithRole, error) {
+	if f.getTargetByNameFn != nil {
+		return f.getTargetByNameFn(name, roles...)
+	}
+	return nil, nil
+}
+func (f *fakeRepository) GetAllTargetMetadataByName(name string) ([]client.TargetSignedStruct, error) {
+	return nil, nil
+}
+func (f *fakeRepository) GetChangelist() (changelist.Changelist, error) {
+	return changelist.NewMemChangelist(), nil
+}
+func (f *fakeRepository) ListRoles() ([]client.RoleWithSignatures, error) { return nil, nil }
+func (f *fakeRepository) GetDelegationRoles() ([]data.Role, error) {
+	if f.getDelegationRolesFn != nil {
+		return f.getDelegationRolesFn()
+	}
+	return nil, nil
+}
+func (f *fakeRepository) AddDelegation(name data.RoleName, delegationKeys []data.PublicKey, paths []string) error {
+	return nil
+}
+func (f *fakeRepository) AddDelegationRoleAndKeys(name data.RoleName, delegationKeys []data.PublicKey) error {
+	return nil
+}
+func (f *fakeRepository) AddDelegationPaths(name data.RoleName, paths []string) error { return nil }
+func (f *fakeRepository) RemoveDelegationKeysAndPaths(name data.RoleName, keyIDs, paths []string) error {
+	return nil
+}
+func (f *fakeRepository) RemoveDelegationRole(name data.RoleName) error { return nil }
+func (f *fakeRepository) RemoveDelegationPaths(name data.RoleName, paths []string) error {
+	return nil
+}
+func (f *fakeRepository) RemoveDelegationKeys(name data.RoleName, keyIDs []string) error {
+	return nil
+}
+func (f *fakeRepository) ClearDelegationPaths(name data.RoleName) error { return nil }
+func (f *fakeRepository) Witness(roles ...data.RoleName) ([]data.RoleName, error) {
+	if f.witnessFn != nil {
+		return f.witnessFn(roles...)
+	}
+	return nil, nil
+}
+func (f *fakeRepository) RotateKey(role data.RoleName, serverManagesKey bool, keyList []string) error {
+	if f.rotateKeyFn != nil {
+		return f.rotateKeyFn(role, serverManagesKey, keyList)
+	}
+	return nil
+}
+func (f *fakeRepository) CryptoService() signed.CryptoService { return f.cryptoService }
+func (f *fakeRepository) GetGUN() data.GUN                    { return "" }
+
+// fakeSignerKey wraps a real crypto.Signer as a data.PrivateKey so
+// TestServerSign can drive the handler through a real signature, the same
+// way the private key returned by the repository's CryptoService would be
+// on the real server.
+type fakeSignerKey struct {
+	id     string
+	signer crypto.Signer
+}
+
+func (k *fakeSignerKey) ID() string        { return k.id }
+func (k *fakeSignerKey) Algorithm() string { return "" }
+func (k *fakeSignerKey) Public() []byte    { return nil }
+func (k *fakeSignerKey) Private() []byte   { return nil }
+func (k *fakeSignerKey) Sign(rand io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return k.signer.Sign(rand, msg, opts)
+}
+
+// fakeCryptoService is a signed.CryptoService whose GetPrivateKey looks up
+// keys by ID; every other method is unused by the tests that construct one.
+type fakeCryptoService struct {
+	keys map[string]data.PrivateKey
+}
+
+func (cs *fakeCryptoService) Create(data.RoleName, data.GUN, string) (data.PublicKey, error) {
+	return nil, nil
+}
+func (cs *fakeCryptoService) AddKey(data.RoleName, data.GUN, data.PrivateKey) error { return nil }
+func (cs *fakeCryptoService) GetKey(string) data.PublicKey                          { return nil }
+func (cs *fakeCryptoService) GetPrivateKey(keyID string) (data.PrivateKey, data.RoleName, error) {
+	key, ok := cs.keys[keyID]
+	if !ok {
+		return nil, "", fmt.Errorf("key %s not found", keyID)
+	}
+	return key, "", nil
+}
+func (cs *fakeCryptoService) RemoveKey(string) error                { return nil }
+func (cs *fakeCryptoService) ListKeys(data.RoleName) []string       { return nil }
+func (cs *fakeCryptoService) ListAllKeys() map[string]data.RoleName { return nil }
+
+// TestServerSign covers chunk0-4: the handler actually signs the payload
+// with the key's real crypto.Signer rather than discarding it and returning
+// codes.Unimplemented, for both the ECDSA and ED25519 key types CryptoService
+// can hand back.
+func TestServerSign(t *testing.T) {
+	payload := []byte("trust me")
+
+	t.Run("ecdsa", func(t *testing.T) {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		repo := &fakeRepository{cryptoService: &fakeCryptoService{keys: map[string]data.PrivateKey{
+			"ecdsa-key": &fakeSignerKey{id: "ecdsa-key", signer: priv},
+		}}}
+		s := NewServer(repo, "/trust", "https://notary.example")
+
+		resp, err := s.Sign(context.Background(), &SignRequest{KeyID: "ecdsa-key", Payload: payload})
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		if !ecdsa.VerifyASN1(&priv.PublicKey, payload, resp.Signature) {
+			t.Fatal("Sign: signature does not verify against the ECDSA public key")
+		}
+	})
+
+	t.Run("ed25519", func(t *testing.T) {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		repo := &fakeRepository{cryptoService: &fakeCryptoService{keys: map[string]data.PrivateKey{
+			"ed25519-key": &fakeSignerKey{id: "ed25519-key", signer: priv},
+		}}}
+		s := NewServer(repo, "/trust", "https://notary.example")
+
+		resp, err := s.Sign(context.Background(), &SignRequest{KeyID: "ed25519-key", Payload: payload})
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		if !ed25519.Verify(pub, payload, resp.Signature) {
+			t.Fatal("Sign: signature does not verify against the ED25519 public key")
+		}
+	})
+
+	t.Run("unknown key propagates an error", func(t *testing.T) {
+		repo := &fakeRepository{cryptoService: &fakeCryptoService{keys: map[string]data.PrivateKey{}}}
+		s := NewServer(repo, "/trust", "https://notary.example")
+
+		if _, err := s.Sign(context.Background(), &SignRequest{KeyID: "missing", Payload: payload}); err == nil {
+			t.Fatal("Sign: got nil error, want the missing-key error surfaced")
+		}
+	})
+}
+
+// TestServerRotateKey covers chunk0-5: the handler forwards the request's
+// fields to Repository.RotateKey untranslated and returns Empty, not a
+// RotateKeyResponse, now that the dead new_key field is gone.
+func TestServerRotateKey(t *testing.T) {
+	var gotRole data.RoleName
+	var gotServerManaged bool
+	var gotKeyIDs []string
+
+	repo := &fakeRepository{
+		rotateKeyFn: func(role data.RoleName, serverManagesKey bool, keyList []string) error {
+			gotRole, gotServerManaged, gotKeyIDs = role, serverManagesKey, keyList
+			return nil
+		},
+	}
+	s := NewServer(repo, "/trust", "https://notary.example")
+
+	resp, err := s.RotateKey(context.Background(), &RotateKeyRequest{Role: "snapshot", ServerManagesKey: true})
+	if err != nil {
+		t.Fatalf("RotateKey: %v", err)
+	}
+	if resp == nil {
+		t.Fatal("RotateKey: got nil response")
+	}
+	if gotRole != "snapshot" || !gotServerManaged || len(gotKeyIDs) != 0 {
+		t.Fatalf("RotateKey: repo called with role=%q serverManaged=%v keyIDs=%v", gotRole, gotServerManaged, gotKeyIDs)
+	}
+}
+
+// TestServerRotateKeyRejectsDelegatedRole covers chunk0-5: rotating a
+// delegated role is rejected before it ever reaches Repository.RotateKey.
+func TestServerRotateKeyRejectsDelegatedRole(t *testing.T) {
+	var called bool
+	repo := &fakeRepository{
+		rotateKeyFn: func(role data.RoleName, serverManagesKey bool, keyList []string) error {
+			called = true
+			return nil
+		},
+	}
+	s := NewServer(repo, "/trust", "https://notary.example")
+
+	_, err := s.RotateKey(context.Background(), &RotateKeyRequest{Role: "targets/releases", KeyIDs: []string{"key-a"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("RotateKey: got %v, want codes.InvalidArgument", err)
+	}
+	if called {
+		t.Fatal("RotateKey: Repository.RotateKey was called for a delegated role")
+	}
+}
+
+// TestServerGetTargetsByName covers chunk0-2: a sub-delegation nested under
+// a requested role is searched too, and an error other than
+// client.ErrNoSuchTarget aborts the call instead of being swallowed as "no
+// match".
+func TestServerGetTargetsByName(t *testing.T) {
+	t.Run("searches sub-delegations under a requested role", func(t *testing.T) {
+		repo := &fakeRepository{
+			getDelegationRolesFn: func() ([]data.Role, error) {
+				return []data.Role{{RootRole: data.RootRole{}, Name: "targets/releases"}}, nil
+			},
+			getTargetByNameFn: func(name string, roles ...data.RoleName) (*client.TargetWithRole, error) {
+				if len(roles) != 1 {
+					t.Fatalf("GetTargetByName: got roles %v, want exactly one", roles)
+				}
+				if roles[0] == "targets" {
+					return nil, client.ErrNoSuchTarget(name)
+				}
+				return &client.TargetWithRole{Target: client.Target{Name: name}, Role: roles[0]}, nil
+			},
+		}
+		s := NewServer(repo, "/trust", "https://notary.example")
+
+		resp, err := s.GetTargetsByName(context.Background(), &TargetByNameAction{
+			Name:  "v1.0",
+			Roles: &RoleNameList{Roles: []string{"targets"}},
+		})
+		if err != nil {
+			t.Fatalf("GetTargetsByName: %v", err)
+		}
+		targets := resp.TargetWithRoleNameList.Targets
+		if len(targets) != 1 || targets[0].Role != "targets/releases" {
+			t.Fatalf("GetTargetsByName: got %+v, want one match from targets/releases", targets)
+		}
+	})
+
+	t.Run("propagates a non-NotFound error instead of swallowing it", func(t *testing.T) {
+		repo := &fakeRepository{
+			getTargetByNameFn: func(name string, roles ...data.RoleName) (*client.TargetWithRole, error) {
+				return nil, storage.ErrOffline{}
+			},
+		}
+		s := NewServer(repo, "/trust", "https://notary.example")
+
+		_, err := s.GetTargetsByName(context.Background(), &TargetByNameAction{
+			Name:  "v1.0",
+			Roles: &RoleNameList{Roles: []string{"targets"}},
+		})
+		if err == nil {
+			t.Fatal("GetTargetsByName: got nil error, want the offline error to propagate")
+		}
+	})
+}
+
+// TestServerWitness covers chunk0-6: the handler forwards Repository.Witness's
+// queued roles untranslated, and a failure (e.g. no local key for a
+// requested role) propagates as an RPC error rather than a partial result.
+func TestServerWitness(t *testing.T) {
+	t.Run("forwards the queued roles", func(t *testing.T) {
+		repo := &fakeRepository{
+			witnessFn: func(roles ...data.RoleName) ([]data.RoleName, error) {
+				return []data.RoleName{"snapshot", "targets/releases"}, nil
+			},
+		}
+		s := NewServer(repo, "/trust", "https://notary.example")
+
+		resp, err := s.Witness(context.Background(), &WitnessRequest{Roles: []string{"snapshot", "targets/releases"}})
+		if err != nil {
+			t.Fatalf("Witness: %v", err)
+		}
+		if len(resp.WitnessedRoles) != 2 || resp.WitnessedRoles[0] != "snapshot" || resp.WitnessedRoles[1] != "targets/releases" {
+			t.Fatalf("Witness: got WitnessedRoles %v", resp.WitnessedRoles)
+		}
+	})
+
+	t.Run("propagates a no-local-key error instead of a partial result", func(t *testing.T) {
+		repo := &fakeRepository{
+			witnessFn: func(roles ...data.RoleName) ([]data.RoleName, error) {
+				return nil, fmt.Errorf("no valid signing keys for role targets/releases")
+			},
+		}
+		s := NewServer(repo, "/trust", "https://notary.example")
+
+		if _, err := s.Witness(context.Background(), &WitnessRequest{Roles: []string{"targets/releases"}}); err == nil {
+			t.Fatal("Witness: got nil error, want the no-local-key error surfaced")
+		}
+	})
+}
+
+// TestToStatus covers chunk0-8 on the server side: each well-known error
+// class maps to a fixed gRPC code and status message that wrapNotaryError
+// can reconstruct, matching the client-side test in client_test.go.
+func TestToStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{"offline", storage.ErrOffline{}, codes.Unavailable, offlineMessage},
+		{"repository not exist", client.ErrRepositoryNotExist{}, codes.NotFound, repositoryNotExistMessage},
+		{"repo not initialized", client.ErrRepoNotInitialized{}, codes.FailedPrecondition, repoNotInitializedMessage},
+		{"insufficient signatures", signed.ErrInsufficientSignatures{FoundKeys: 1, NeededKeys: 3}, codes.FailedPrecondition, "insufficient signatures: found 1 of 3 required"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			st, ok := status.FromError(toStatus(tc.err))
+			if !ok {
+				t.Fatalf("toStatus(%v): not a gRPC status", tc.err)
+			}
+			if st.Code() != tc.code {
+				t.Fatalf("toStatus(%v): got code %v, want %v", tc.err, st.Code(), tc.code)
+			}
+			if st.Message() != tc.msg {
+				t.Fatalf("toStatus(%v): got message %q, want %q", tc.err, st.Message(), tc.msg)
+			}
+		})
+	}
+
+	t.Run("unknown error class passes through as codes.Unknown with its own message", func(t *testing.T) {
+		got := toStatus(errExample{})
+		st, ok := status.FromError(got)
+		if !ok || st.Code() != codes.Unknown {
+			t.Fatalf("toStatus(errExample{}): got %v, want a codes.Unknown status", got)
+		}
+		if st.Message() != "unmapped error" {
+			t.Fatalf("toStatus(errExample{}): got message %q, want %q", st.Message(), "unmapped error")
+		}
+	})
+}
+
+type errExample struct{}
+
+func (errExample) Error() string { return "unmapped error" }
@@ -0,0 +1,267 @@
+// Go types for the messages in api.proto, written by hand. They are plain
+// structs: they carry no protobuf field tags and do not implement
+// proto.Message.
+
+package api
+
+type Empty struct{}
+
+type PublicKey struct {
+	Algorithm string
+	Public    []byte
+}
+
+type RoleNameList struct {
+	Roles []string
+}
+
+func (m *RoleNameList) GetRoles() []string {
+	if m == nil {
+		return nil
+	}
+	return m.Roles
+}
+
+type InitMessage struct {
+	RootKeyIDs         []string
+	ServerManagedRoles *RoleNameList
+	RootCerts          []*PublicKey
+}
+
+func (m *InitMessage) GetServerManagedRoles() *RoleNameList {
+	if m == nil {
+		return nil
+	}
+	return m.ServerManagedRoles
+}
+
+type Target struct {
+	Gun    string
+	Name   string
+	Length int64
+	Hashes map[string][]byte
+}
+
+func (t *Target) GetName() string {
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+func (t *Target) GetLength() int64 {
+	if t == nil {
+		return 0
+	}
+	return t.Length
+}
+
+type TargetWithRole struct {
+	Target *Target
+	Role   string
+}
+
+type TargetWithRoleNameList struct {
+	Targets []*TargetWithRole
+}
+
+type TargetWithRoleList struct {
+	TargetWithRoleNameList *TargetWithRoleNameList
+}
+
+type TargetByNameAction struct {
+	Name  string
+	Roles *RoleNameList
+}
+
+func (m *TargetByNameAction) GetRoles() *RoleNameList {
+	if m == nil {
+		return nil
+	}
+	return m.Roles
+}
+
+type TargetWithRoleResponse struct {
+	TargetWithRole *TargetWithRole
+}
+
+type TargetName struct {
+	Name string
+}
+
+type Signature struct {
+	KeyID     string
+	Method    string
+	Signature []byte
+	IsValid   bool
+}
+
+type DelegationRootRole struct {
+	Keys      map[string]*PublicKey
+	Name      string
+	Threshold int64
+	Paths     []string
+}
+
+type TargetSigned struct {
+	Target     *Target
+	Role       *DelegationRootRole
+	Signatures []*Signature
+}
+
+type TargetSignedList struct {
+	Targets []*TargetSigned
+}
+
+type TargetSignedListResponse struct {
+	TargetSignedList *TargetSignedList
+}
+
+type Change struct {
+	Action  string
+	Scope   string
+	Type    string
+	Path    string
+	Content []byte
+}
+
+type Changelist struct {
+	Changes []*Change
+}
+
+type ChangelistResponse struct {
+	Changelist *Changelist
+}
+
+type RootRole struct {
+	KeyIDs    []string
+	Threshold int64
+}
+
+type Role struct {
+	Name     string
+	RootRole *RootRole
+	Paths    []string
+}
+
+type RoleList struct {
+	Roles []*Role
+}
+
+type RoleWithSignatures struct {
+	Role       *Role
+	Signatures []*Signature
+}
+
+type RoleWithSignaturesList struct {
+	RoleWithSignatures []*RoleWithSignatures
+}
+
+type RoleWithSignaturesListResponse struct {
+	RoleWithSignaturesList *RoleWithSignaturesList
+}
+
+type CreateKeyRequest struct {
+	Role      string
+	Gun       string
+	Algorithm string
+}
+
+type KeyID struct {
+	KeyID string
+}
+
+type KeyInfo struct {
+	Algorithm string
+	Public    []byte
+	Role      string
+}
+
+type RoleQuery struct {
+	Role string
+}
+
+type KeyIDList struct {
+	KeyIDs []string
+}
+
+type KeyRoleEntry struct {
+	KeyID string
+	Role  string
+}
+
+type KeyRoleMap struct {
+	Entries []*KeyRoleEntry
+}
+
+type SignRequest struct {
+	KeyID   string
+	Payload []byte
+}
+
+type SignResponse struct {
+	Signature []byte
+}
+
+type DeleteTrustDataRequest struct {
+	Gun          string
+	DeleteRemote bool
+}
+
+type DeleteTrustDataResponse struct {
+	LocalDeleted  bool
+	RemoteDeleted bool
+}
+
+type WitnessRequest struct {
+	Gun   string
+	Roles []string
+}
+
+type WitnessResponse struct {
+	WitnessedRoles []string
+}
+
+type RotateKeyRequest struct {
+	Gun              string
+	Role             string
+	ServerManagesKey bool
+	KeyIDs           []string
+}
+
+type DelegationName struct {
+	Gun  string
+	Name string
+}
+
+type DelegationRoleAndKeys struct {
+	Gun  string
+	Name string
+	Keys []*PublicKey
+}
+
+type DelegationPaths struct {
+	Gun   string
+	Name  string
+	Paths []string
+}
+
+type DelegationRoleAndKeysAndPaths struct {
+	Gun   string
+	Name  string
+	Keys  []*PublicKey
+	Paths []string
+}
+
+type DelegationKeysAndPaths struct {
+	Gun    string
+	Name   string
+	KeyIDs []string
+	Paths  []string
+}
+
+type DelegationKeys struct {
+	Gun    string
+	Name   string
+	KeyIDs []string
+}